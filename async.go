@@ -0,0 +1,197 @@
+package go_ml
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type asyncNode struct {
+	fallback HTMLContent
+	fn       func(ctx context.Context) (HTMLContent, error)
+}
+
+type asyncResult struct {
+	id   int
+	path string
+	// scope of the async content, to build the resolved one
+	ctx     context.Context
+	slots   []slotScope
+	content HTMLContent
+	err     error
+}
+
+// async contents started by a build and not written yet
+type asyncState struct {
+	seq     int
+	pending int
+	done    chan asyncResult
+	cancels []context.CancelFunc
+}
+
+// Async writes the fallback in place and keeps building the rest of the
+// document while the content is computed concurrently. Once the document
+// is written, each resolved content is appended (in the order they are
+// resolved) inside a <template>, along with an inline script replacing the
+// fallback by it, i.g.:
+//
+//	Async(P()(Text("loading...")), func(ctx context.Context) (HTMLContent, error) {
+//		todos, err := db.GetAll(ctx)
+//		return ListOfTodos(todos...), err
+//	})
+//
+// The function gets the build context, with the values of the Provide
+// contents above it. When it fails the fallback is kept and the error is
+// returned by the build after all the other contents are written.
+func Async(fallback HTMLContent, fn func(ctx context.Context) (HTMLContent, error)) HTMLContent {
+	return HTMLContent{async: &asyncNode{fallback: fallback, fn: fn}, ctType: AsyncContent}
+}
+
+const (
+	asyncPathName          = "#async"
+	asyncPlaceholderPrefix = "goml-ph-"
+	asyncTemplatePrefix    = "goml-as-"
+	// replaces everything from the placeholder to its closing comment by
+	// the template contents
+	asyncSwapScript = `<script>function gomlSwap(id){` +
+		`var p=document.getElementById("` + asyncPlaceholderPrefix + `"+id),t=document.getElementById("` + asyncTemplatePrefix + `"+id);` +
+		`if(!p||!t)return;` +
+		`var n=p.nextSibling,end="/` + asyncPlaceholderPrefix + `"+id;` +
+		`while(n&&!(n.nodeType===8&&n.data===end)){var x=n.nextSibling;n.remove();n=x}` +
+		`if(n)n.remove();p.replaceWith(t.content);t.remove()}</script>`
+)
+
+func (state *asyncState) cancelAll() {
+	for _, cancel := range state.cancels {
+		cancel()
+	}
+}
+
+// parseAsync starts the async function and writes the fallback between
+// the placeholder markers.
+func (cfg *buildConfig) parseAsync(node *asyncNode, tagDepth int) (int, error) {
+	for _, p := range cfg.path {
+		if strings.HasPrefix(p.tagName, cachedPathPrefix) {
+			return 0, cfg.buildErr(ErrCachedAsync)
+		}
+	}
+
+	if cfg.async.done == nil {
+		cfg.async.done = make(chan asyncResult)
+	}
+
+	id := cfg.async.seq
+	cfg.async.seq++
+	cfg.async.pending++
+
+	ctx, cancel := context.WithCancel(cfg.ctx)
+	cfg.async.cancels = append(cfg.async.cancels, cancel)
+	res := asyncResult{id: id, path: cfg.path.String(), ctx: cfg.ctx, slots: cfg.slots[:len(cfg.slots):len(cfg.slots)]}
+	go func(done chan<- asyncResult) {
+		res.content, res.err = node.fn(ctx)
+		select {
+		case done <- res:
+		case <-ctx.Done():
+		}
+	}(cfg.async.done)
+
+	out := cfg.out
+	start := out.written
+	outer, err := cfg.startOwnLine(tagDepth)
+	if err != nil {
+		return out.since(start), err
+	}
+	defer func() { cfg.pretty = outer }()
+
+	strID := strconv.Itoa(id)
+	out.WriteString(`<template id="` + asyncPlaceholderPrefix)
+	out.WriteString(strID)
+	if _, err := out.WriteString(`"></template>`); err != nil {
+		return out.since(start), err
+	}
+
+	if _, err := cfg.parseContent(node.fallback, -1, tagDepth); err != nil {
+		return out.since(start), err
+	}
+
+	out.WriteString(`<!--/` + asyncPlaceholderPrefix)
+	out.WriteString(strID)
+	_, err = out.WriteString(`-->`)
+	return out.since(start), err
+}
+
+// writeAsyncs waits for the pending async contents, writing each one as
+// soon as it's resolved. Resolved contents can hold other async contents,
+// which are waited as well.
+func (cfg *buildConfig) writeAsyncs() (int, error) {
+	var totalWritten int
+	var errs []error
+
+	if cfg.async.pending == 0 {
+		return 0, nil
+	}
+
+	// the document is done, so the browser can already show it
+	if err := cfg.flush(); err != nil {
+		return 0, err
+	}
+	n, err := cfg.out.WriteString(asyncSwapScript)
+	totalWritten += n
+	if err != nil {
+		return totalWritten, err
+	}
+
+	for cfg.async.pending > 0 {
+		var res asyncResult
+		select {
+		case res = <-cfg.async.done:
+		case <-cfg.ctx.Done():
+			return totalWritten, cfg.buildErr(cfg.ctx.Err())
+		}
+		cfg.async.pending--
+
+		if res.err != nil {
+			errs = append(errs, &BuildError{Path: res.path, TagName: asyncPathName, Err: res.err})
+			cfg.debug.logger.Error("Async content failed: ", "path", res.path, "error", res.err)
+			continue
+		}
+
+		n, err := cfg.writeAsyncResult(res)
+		totalWritten += n
+		if err != nil {
+			return totalWritten, err
+		}
+		if err := cfg.flush(); err != nil {
+			return totalWritten, err
+		}
+	}
+
+	return totalWritten, errors.Join(errs...)
+}
+
+func (cfg *buildConfig) writeAsyncResult(res asyncResult) (int, error) {
+	out := cfg.out
+	start := out.written
+	strID := strconv.Itoa(res.id)
+	out.WriteString(`<template id="` + asyncTemplatePrefix)
+	out.WriteString(strID)
+	if _, err := out.WriteString(`">`); err != nil {
+		return out.since(start), err
+	}
+
+	outerCtx, outerSlots, outerPretty := cfg.ctx, cfg.slots, cfg.pretty
+	cfg.ctx, cfg.slots, cfg.pretty = res.ctx, res.slots, prettyState{}
+	cfg.path.push(asyncPathName, res.id)
+	_, err := cfg.parseContent(res.content, -1, 1)
+	cfg.path.pop()
+	cfg.ctx, cfg.slots, cfg.pretty = outerCtx, outerSlots, outerPretty
+	if err != nil {
+		return out.since(start), err
+	}
+
+	out.WriteString(`</template><script>gomlSwap(`)
+	out.WriteString(strID)
+	_, err = out.WriteString(`)</script>`)
+	return out.since(start), err
+}
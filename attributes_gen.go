@@ -0,0 +1,668 @@
+// Code generated by go run ./gen; DO NOT EDIT.
+
+package go_ml
+
+// AbbrAttr builds the abbr attribute.
+func AbbrAttr(values ...string) HTMLAttribute {
+	return Attr("abbr", DoubleQuoted, values...)
+}
+
+// Accept builds the accept attribute.
+func Accept(values ...string) HTMLAttribute {
+	return Attr("accept", DoubleQuoted, values...)
+}
+
+// AcceptCharset builds the accept-charset attribute.
+func AcceptCharset(values ...string) HTMLAttribute {
+	return Attr("accept-charset", DoubleQuoted, values...)
+}
+
+// Accesskey builds the accesskey attribute.
+func Accesskey(values ...string) HTMLAttribute {
+	return Attr("accesskey", DoubleQuoted, values...)
+}
+
+// Action builds the action attribute.
+func Action(values ...string) HTMLAttribute {
+	return Attr("action", URL, values...)
+}
+
+// Allow builds the allow attribute.
+func Allow(values ...string) HTMLAttribute {
+	return Attr("allow", DoubleQuoted, values...)
+}
+
+// Allowfullscreen builds the boolean allowfullscreen attribute.
+func Allowfullscreen() HTMLAttribute {
+	return Attr("allowfullscreen", Single)
+}
+
+// Alt builds the alt attribute.
+func Alt(values ...string) HTMLAttribute {
+	return Attr("alt", DoubleQuoted, values...)
+}
+
+// As builds the as attribute.
+func As(values ...string) HTMLAttribute {
+	return Attr("as", DoubleQuoted, values...)
+}
+
+// AsyncAttr builds the boolean async attribute.
+func AsyncAttr() HTMLAttribute {
+	return Attr("async", Single)
+}
+
+// Autocapitalize builds the autocapitalize attribute.
+func Autocapitalize(values ...string) HTMLAttribute {
+	return Attr("autocapitalize", DoubleQuoted, values...)
+}
+
+// Autocomplete builds the autocomplete attribute.
+func Autocomplete(values ...string) HTMLAttribute {
+	return Attr("autocomplete", DoubleQuoted, values...)
+}
+
+// Autofocus builds the boolean autofocus attribute.
+func Autofocus() HTMLAttribute {
+	return Attr("autofocus", Single)
+}
+
+// Autoplay builds the boolean autoplay attribute.
+func Autoplay() HTMLAttribute {
+	return Attr("autoplay", Single)
+}
+
+// Blocking builds the blocking attribute.
+func Blocking(values ...string) HTMLAttribute {
+	return Attr("blocking", DoubleQuoted, values...)
+}
+
+// Charset builds the charset attribute.
+func Charset(values ...string) HTMLAttribute {
+	return Attr("charset", DoubleQuoted, values...)
+}
+
+// Checked builds the boolean checked attribute.
+func Checked() HTMLAttribute {
+	return Attr("checked", Single)
+}
+
+// CiteAttr builds the cite attribute.
+func CiteAttr(values ...string) HTMLAttribute {
+	return Attr("cite", URL, values...)
+}
+
+// ClassNames builds the class attribute.
+func ClassNames(values ...string) HTMLAttribute {
+	return Attr("class", DoubleQuoted, values...)
+}
+
+// Color builds the color attribute.
+func Color(values ...string) HTMLAttribute {
+	return Attr("color", DoubleQuoted, values...)
+}
+
+// Cols builds the cols attribute.
+func Cols(values ...string) HTMLAttribute {
+	return Attr("cols", DoubleQuoted, values...)
+}
+
+// Colspan builds the colspan attribute.
+func Colspan(values ...string) HTMLAttribute {
+	return Attr("colspan", DoubleQuoted, values...)
+}
+
+// Content builds the content attribute.
+func Content(values ...string) HTMLAttribute {
+	return Attr("content", DoubleQuoted, values...)
+}
+
+// Contenteditable builds the contenteditable attribute.
+func Contenteditable(values ...string) HTMLAttribute {
+	return Attr("contenteditable", DoubleQuoted, values...)
+}
+
+// Controls builds the boolean controls attribute.
+func Controls() HTMLAttribute {
+	return Attr("controls", Single)
+}
+
+// Coords builds the coords attribute.
+func Coords(values ...string) HTMLAttribute {
+	return Attr("coords", DoubleQuoted, values...)
+}
+
+// Crossorigin builds the crossorigin attribute.
+func Crossorigin(values ...string) HTMLAttribute {
+	return Attr("crossorigin", DoubleQuoted, values...)
+}
+
+// DataAttr builds the data attribute.
+func DataAttr(values ...string) HTMLAttribute {
+	return Attr("data", URL, values...)
+}
+
+// Datetime builds the datetime attribute.
+func Datetime(values ...string) HTMLAttribute {
+	return Attr("datetime", DoubleQuoted, values...)
+}
+
+// Decoding builds the decoding attribute.
+func Decoding(values ...string) HTMLAttribute {
+	return Attr("decoding", DoubleQuoted, values...)
+}
+
+// Default builds the boolean default attribute.
+func Default() HTMLAttribute {
+	return Attr("default", Single)
+}
+
+// Defer builds the boolean defer attribute.
+func Defer() HTMLAttribute {
+	return Attr("defer", Single)
+}
+
+// Dir builds the dir attribute.
+func Dir(values ...string) HTMLAttribute {
+	return Attr("dir", DoubleQuoted, values...)
+}
+
+// Dirname builds the dirname attribute.
+func Dirname(values ...string) HTMLAttribute {
+	return Attr("dirname", DoubleQuoted, values...)
+}
+
+// Disabled builds the boolean disabled attribute.
+func Disabled() HTMLAttribute {
+	return Attr("disabled", Single)
+}
+
+// Download builds the download attribute.
+func Download(values ...string) HTMLAttribute {
+	return Attr("download", DoubleQuoted, values...)
+}
+
+// Draggable builds the draggable attribute.
+func Draggable(values ...string) HTMLAttribute {
+	return Attr("draggable", DoubleQuoted, values...)
+}
+
+// Enctype builds the enctype attribute.
+func Enctype(values ...string) HTMLAttribute {
+	return Attr("enctype", DoubleQuoted, values...)
+}
+
+// Enterkeyhint builds the enterkeyhint attribute.
+func Enterkeyhint(values ...string) HTMLAttribute {
+	return Attr("enterkeyhint", DoubleQuoted, values...)
+}
+
+// Fetchpriority builds the fetchpriority attribute.
+func Fetchpriority(values ...string) HTMLAttribute {
+	return Attr("fetchpriority", DoubleQuoted, values...)
+}
+
+// For builds the for attribute.
+func For(values ...string) HTMLAttribute {
+	return Attr("for", DoubleQuoted, values...)
+}
+
+// FormAttr builds the form attribute.
+func FormAttr(values ...string) HTMLAttribute {
+	return Attr("form", DoubleQuoted, values...)
+}
+
+// Formaction builds the formaction attribute.
+func Formaction(values ...string) HTMLAttribute {
+	return Attr("formaction", URL, values...)
+}
+
+// Formenctype builds the formenctype attribute.
+func Formenctype(values ...string) HTMLAttribute {
+	return Attr("formenctype", DoubleQuoted, values...)
+}
+
+// Formmethod builds the formmethod attribute.
+func Formmethod(values ...string) HTMLAttribute {
+	return Attr("formmethod", DoubleQuoted, values...)
+}
+
+// Formnovalidate builds the boolean formnovalidate attribute.
+func Formnovalidate() HTMLAttribute {
+	return Attr("formnovalidate", Single)
+}
+
+// Formtarget builds the formtarget attribute.
+func Formtarget(values ...string) HTMLAttribute {
+	return Attr("formtarget", DoubleQuoted, values...)
+}
+
+// Headers builds the headers attribute.
+func Headers(values ...string) HTMLAttribute {
+	return Attr("headers", DoubleQuoted, values...)
+}
+
+// Height builds the height attribute.
+func Height(values ...string) HTMLAttribute {
+	return Attr("height", DoubleQuoted, values...)
+}
+
+// Hidden builds the boolean hidden attribute.
+func Hidden() HTMLAttribute {
+	return Attr("hidden", Single)
+}
+
+// High builds the high attribute.
+func High(values ...string) HTMLAttribute {
+	return Attr("high", DoubleQuoted, values...)
+}
+
+// Href builds the href attribute.
+func Href(values ...string) HTMLAttribute {
+	return Attr("href", URL, values...)
+}
+
+// Hreflang builds the hreflang attribute.
+func Hreflang(values ...string) HTMLAttribute {
+	return Attr("hreflang", DoubleQuoted, values...)
+}
+
+// HttpEquiv builds the http-equiv attribute.
+func HttpEquiv(values ...string) HTMLAttribute {
+	return Attr("http-equiv", DoubleQuoted, values...)
+}
+
+// Id builds the id attribute.
+func Id(values ...string) HTMLAttribute {
+	return Attr("id", DoubleQuoted, values...)
+}
+
+// Imagesizes builds the imagesizes attribute.
+func Imagesizes(values ...string) HTMLAttribute {
+	return Attr("imagesizes", DoubleQuoted, values...)
+}
+
+// Imagesrcset builds the imagesrcset attribute.
+func Imagesrcset(values ...string) HTMLAttribute {
+	return Attr("imagesrcset", DoubleQuoted, values...)
+}
+
+// Inert builds the boolean inert attribute.
+func Inert() HTMLAttribute {
+	return Attr("inert", Single)
+}
+
+// Inputmode builds the inputmode attribute.
+func Inputmode(values ...string) HTMLAttribute {
+	return Attr("inputmode", DoubleQuoted, values...)
+}
+
+// Integrity builds the integrity attribute.
+func Integrity(values ...string) HTMLAttribute {
+	return Attr("integrity", DoubleQuoted, values...)
+}
+
+// Is builds the is attribute.
+func Is(values ...string) HTMLAttribute {
+	return Attr("is", DoubleQuoted, values...)
+}
+
+// Ismap builds the boolean ismap attribute.
+func Ismap() HTMLAttribute {
+	return Attr("ismap", Single)
+}
+
+// Itemid builds the itemid attribute.
+func Itemid(values ...string) HTMLAttribute {
+	return Attr("itemid", URL, values...)
+}
+
+// Itemprop builds the itemprop attribute.
+func Itemprop(values ...string) HTMLAttribute {
+	return Attr("itemprop", DoubleQuoted, values...)
+}
+
+// Itemref builds the itemref attribute.
+func Itemref(values ...string) HTMLAttribute {
+	return Attr("itemref", DoubleQuoted, values...)
+}
+
+// Itemscope builds the boolean itemscope attribute.
+func Itemscope() HTMLAttribute {
+	return Attr("itemscope", Single)
+}
+
+// Itemtype builds the itemtype attribute.
+func Itemtype(values ...string) HTMLAttribute {
+	return Attr("itemtype", URL, values...)
+}
+
+// Kind builds the kind attribute.
+func Kind(values ...string) HTMLAttribute {
+	return Attr("kind", DoubleQuoted, values...)
+}
+
+// LabelAttr builds the label attribute.
+func LabelAttr(values ...string) HTMLAttribute {
+	return Attr("label", DoubleQuoted, values...)
+}
+
+// Lang builds the lang attribute.
+func Lang(values ...string) HTMLAttribute {
+	return Attr("lang", DoubleQuoted, values...)
+}
+
+// List builds the list attribute.
+func List(values ...string) HTMLAttribute {
+	return Attr("list", DoubleQuoted, values...)
+}
+
+// Loading builds the loading attribute.
+func Loading(values ...string) HTMLAttribute {
+	return Attr("loading", DoubleQuoted, values...)
+}
+
+// Loop builds the boolean loop attribute.
+func Loop() HTMLAttribute {
+	return Attr("loop", Single)
+}
+
+// Low builds the low attribute.
+func Low(values ...string) HTMLAttribute {
+	return Attr("low", DoubleQuoted, values...)
+}
+
+// Max builds the max attribute.
+func Max(values ...string) HTMLAttribute {
+	return Attr("max", DoubleQuoted, values...)
+}
+
+// Maxlength builds the maxlength attribute.
+func Maxlength(values ...string) HTMLAttribute {
+	return Attr("maxlength", DoubleQuoted, values...)
+}
+
+// Media builds the media attribute.
+func Media(values ...string) HTMLAttribute {
+	return Attr("media", DoubleQuoted, values...)
+}
+
+// Method builds the method attribute.
+func Method(values ...string) HTMLAttribute {
+	return Attr("method", DoubleQuoted, values...)
+}
+
+// Min builds the min attribute.
+func Min(values ...string) HTMLAttribute {
+	return Attr("min", DoubleQuoted, values...)
+}
+
+// Minlength builds the minlength attribute.
+func Minlength(values ...string) HTMLAttribute {
+	return Attr("minlength", DoubleQuoted, values...)
+}
+
+// Multiple builds the boolean multiple attribute.
+func Multiple() HTMLAttribute {
+	return Attr("multiple", Single)
+}
+
+// Muted builds the boolean muted attribute.
+func Muted() HTMLAttribute {
+	return Attr("muted", Single)
+}
+
+// Name builds the name attribute.
+func Name(values ...string) HTMLAttribute {
+	return Attr("name", DoubleQuoted, values...)
+}
+
+// Nomodule builds the boolean nomodule attribute.
+func Nomodule() HTMLAttribute {
+	return Attr("nomodule", Single)
+}
+
+// Nonce builds the nonce attribute.
+func Nonce(values ...string) HTMLAttribute {
+	return Attr("nonce", DoubleQuoted, values...)
+}
+
+// Novalidate builds the boolean novalidate attribute.
+func Novalidate() HTMLAttribute {
+	return Attr("novalidate", Single)
+}
+
+// Open builds the boolean open attribute.
+func Open() HTMLAttribute {
+	return Attr("open", Single)
+}
+
+// Optimum builds the optimum attribute.
+func Optimum(values ...string) HTMLAttribute {
+	return Attr("optimum", DoubleQuoted, values...)
+}
+
+// Pattern builds the pattern attribute.
+func Pattern(values ...string) HTMLAttribute {
+	return Attr("pattern", DoubleQuoted, values...)
+}
+
+// Ping builds the ping attribute.
+func Ping(values ...string) HTMLAttribute {
+	return Attr("ping", DoubleQuoted, values...)
+}
+
+// PlaceHolder builds the placeholder attribute.
+func PlaceHolder(values ...string) HTMLAttribute {
+	return Attr("placeholder", DoubleQuoted, values...)
+}
+
+// Playsinline builds the boolean playsinline attribute.
+func Playsinline() HTMLAttribute {
+	return Attr("playsinline", Single)
+}
+
+// Popover builds the popover attribute.
+func Popover(values ...string) HTMLAttribute {
+	return Attr("popover", DoubleQuoted, values...)
+}
+
+// Popovertarget builds the popovertarget attribute.
+func Popovertarget(values ...string) HTMLAttribute {
+	return Attr("popovertarget", DoubleQuoted, values...)
+}
+
+// Popovertargetaction builds the popovertargetaction attribute.
+func Popovertargetaction(values ...string) HTMLAttribute {
+	return Attr("popovertargetaction", DoubleQuoted, values...)
+}
+
+// Poster builds the poster attribute.
+func Poster(values ...string) HTMLAttribute {
+	return Attr("poster", URL, values...)
+}
+
+// Preload builds the preload attribute.
+func Preload(values ...string) HTMLAttribute {
+	return Attr("preload", DoubleQuoted, values...)
+}
+
+// Readonly builds the boolean readonly attribute.
+func Readonly() HTMLAttribute {
+	return Attr("readonly", Single)
+}
+
+// Referrerpolicy builds the referrerpolicy attribute.
+func Referrerpolicy(values ...string) HTMLAttribute {
+	return Attr("referrerpolicy", DoubleQuoted, values...)
+}
+
+// Rel builds the rel attribute.
+func Rel(values ...string) HTMLAttribute {
+	return Attr("rel", DoubleQuoted, values...)
+}
+
+// Required builds the boolean required attribute.
+func Required() HTMLAttribute {
+	return Attr("required", Single)
+}
+
+// Reversed builds the boolean reversed attribute.
+func Reversed() HTMLAttribute {
+	return Attr("reversed", Single)
+}
+
+// Rows builds the rows attribute.
+func Rows(values ...string) HTMLAttribute {
+	return Attr("rows", DoubleQuoted, values...)
+}
+
+// Rowspan builds the rowspan attribute.
+func Rowspan(values ...string) HTMLAttribute {
+	return Attr("rowspan", DoubleQuoted, values...)
+}
+
+// Sandbox builds the sandbox attribute.
+func Sandbox(values ...string) HTMLAttribute {
+	return Attr("sandbox", DoubleQuoted, values...)
+}
+
+// Scope builds the scope attribute.
+func Scope(values ...string) HTMLAttribute {
+	return Attr("scope", DoubleQuoted, values...)
+}
+
+// Selected builds the boolean selected attribute.
+func Selected() HTMLAttribute {
+	return Attr("selected", Single)
+}
+
+// Shadowrootclonable builds the boolean shadowrootclonable attribute.
+func Shadowrootclonable() HTMLAttribute {
+	return Attr("shadowrootclonable", Single)
+}
+
+// Shadowrootdelegatesfocus builds the boolean shadowrootdelegatesfocus attribute.
+func Shadowrootdelegatesfocus() HTMLAttribute {
+	return Attr("shadowrootdelegatesfocus", Single)
+}
+
+// Shadowrootmode builds the shadowrootmode attribute.
+func Shadowrootmode(values ...string) HTMLAttribute {
+	return Attr("shadowrootmode", DoubleQuoted, values...)
+}
+
+// Shape builds the shape attribute.
+func Shape(values ...string) HTMLAttribute {
+	return Attr("shape", DoubleQuoted, values...)
+}
+
+// Size builds the size attribute.
+func Size(values ...string) HTMLAttribute {
+	return Attr("size", DoubleQuoted, values...)
+}
+
+// Sizes builds the sizes attribute.
+func Sizes(values ...string) HTMLAttribute {
+	return Attr("sizes", DoubleQuoted, values...)
+}
+
+// SlotAttr builds the slot attribute.
+func SlotAttr(values ...string) HTMLAttribute {
+	return Attr("slot", DoubleQuoted, values...)
+}
+
+// SpanAttr builds the span attribute.
+func SpanAttr(values ...string) HTMLAttribute {
+	return Attr("span", DoubleQuoted, values...)
+}
+
+// Spellcheck builds the spellcheck attribute.
+func Spellcheck(values ...string) HTMLAttribute {
+	return Attr("spellcheck", DoubleQuoted, values...)
+}
+
+// Src builds the src attribute.
+func Src(values ...string) HTMLAttribute {
+	return Attr("src", URL, values...)
+}
+
+// Srcdoc builds the srcdoc attribute.
+func Srcdoc(values ...string) HTMLAttribute {
+	return Attr("srcdoc", DoubleQuoted, values...)
+}
+
+// Srclang builds the srclang attribute.
+func Srclang(values ...string) HTMLAttribute {
+	return Attr("srclang", DoubleQuoted, values...)
+}
+
+// Srcset builds the srcset attribute.
+func Srcset(values ...string) HTMLAttribute {
+	return Attr("srcset", DoubleQuoted, values...)
+}
+
+// Start builds the start attribute.
+func Start(values ...string) HTMLAttribute {
+	return Attr("start", DoubleQuoted, values...)
+}
+
+// Step builds the step attribute.
+func Step(values ...string) HTMLAttribute {
+	return Attr("step", DoubleQuoted, values...)
+}
+
+// StyleAttr builds the style attribute.
+func StyleAttr(values ...string) HTMLAttribute {
+	return Attr("style", DoubleQuoted, values...)
+}
+
+// Tabindex builds the tabindex attribute.
+func Tabindex(values ...string) HTMLAttribute {
+	return Attr("tabindex", DoubleQuoted, values...)
+}
+
+// Target builds the target attribute.
+func Target(values ...string) HTMLAttribute {
+	return Attr("target", DoubleQuoted, values...)
+}
+
+// TitleAttr builds the title attribute.
+func TitleAttr(values ...string) HTMLAttribute {
+	return Attr("title", DoubleQuoted, values...)
+}
+
+// Translate builds the translate attribute.
+func Translate(values ...string) HTMLAttribute {
+	return Attr("translate", DoubleQuoted, values...)
+}
+
+// Type builds the type attribute.
+func Type(values ...string) HTMLAttribute {
+	return Attr("type", DoubleQuoted, values...)
+}
+
+// Usemap builds the usemap attribute.
+func Usemap(values ...string) HTMLAttribute {
+	return Attr("usemap", DoubleQuoted, values...)
+}
+
+// Value builds the value attribute.
+func Value(values ...string) HTMLAttribute {
+	return Attr("value", DoubleQuoted, values...)
+}
+
+// Width builds the width attribute.
+func Width(values ...string) HTMLAttribute {
+	return Attr("width", DoubleQuoted, values...)
+}
+
+// Wrap builds the wrap attribute.
+func Wrap(values ...string) HTMLAttribute {
+	return Attr("wrap", DoubleQuoted, values...)
+}
+
+// Writingsuggestions builds the writingsuggestions attribute.
+func Writingsuggestions(values ...string) HTMLAttribute {
+	return Attr("writingsuggestions", DoubleQuoted, values...)
+}
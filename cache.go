@@ -0,0 +1,283 @@
+package go_ml
+
+import (
+	"container/list"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// Cache stores the rendered Cached contents as opaque bytes.
+// Implementations must be safe for concurrent use, since many builds can
+// share the cache.
+type Cache interface {
+	// Get returns the bytes stored for the key, if they aren't expired.
+	// The returned bytes must not be modified.
+	Get(key string) ([]byte, bool)
+	// Set stores the bytes for the key, which expire after the ttl. A zero
+	// or negative ttl never expires.
+	Set(key string, value []byte, ttl time.Duration)
+	// Delete invalidates the key.
+	Delete(key string)
+}
+
+type cachedNode struct {
+	key string
+	ttl time.Duration
+	fn  func() HTMLContent
+}
+
+// Cached builds the content once and writes the stored bytes on the next
+// builds, until the ttl expires or the key is invalidated, i.g.:
+//
+//	func LoadTodoRow(t TodoList) HTMLContent {
+//		return Cached("todo-row-"+t.id, time.Minute, func() HTMLContent {
+//			return Use(TodoRow{todo: t})
+//		})
+//	}
+//
+// The key must hold everything the content depends on, including the
+// build options changing the output, like the indentation. Without a
+// cache (see WithCache) the content is built every time.
+//
+// Since the bytes are reused, the elements of a cached content are only
+// counted by the build metrics and checked by WithUniqueIDs when the
+// content is built. Cached contents can't hold Async contents, whose
+// results would be lost on the next builds. With indentation, cached
+// contents are written on their own line, indented for the depth they're
+// written at.
+func Cached(key string, ttl time.Duration, fn func() HTMLContent) HTMLContent {
+	return HTMLContent{cached: &cachedNode{key: key, ttl: ttl, fn: fn}, ctType: CachedContent}
+}
+
+// Store the Cached contents on the given cache, which is usually shared by
+// all the builds, i.g.: NewMemoryCache(1000).
+func WithCache(cache Cache) buildOpt {
+	return func(config *buildConfig) {
+		config.cache = cache
+	}
+}
+
+const cachedPathPrefix = "#cached("
+
+func cachedPathName(node *cachedNode) string {
+	return cachedPathPrefix + node.key + ")"
+}
+
+// parseCached writes the cached bytes or builds the content, recording
+// what's written to store it.
+func (cfg *buildConfig) parseCached(node *cachedNode, tagDepth int) (int, error) {
+	start := cfg.out.written
+	// the stored bytes don't depend on what's written around them
+	outer, err := cfg.startOwnLine(tagDepth)
+	if err != nil {
+		return cfg.out.since(start), err
+	}
+	defer func() { cfg.pretty = outer }()
+
+	if cfg.cache == nil {
+		_, err := cfg.parseContent(node.fn(), -1, tagDepth)
+		return cfg.out.since(start), err
+	}
+
+	indent := (tagDepth - 1) * int(cfg.indentitation.indentationLevel)
+	// bytes stored by something else are built again
+	if b, ok := cfg.cache.Get(node.key); ok {
+		if breaks, body, ok := decodeCached(b); ok {
+			err := cfg.writeCached(breaks, body, indent)
+			return cfg.out.since(start), err
+		}
+	}
+
+	rec := new(recorder)
+	cfg.out.recorders = append(cfg.out.recorders, rec)
+	_, err = cfg.parseContent(node.fn(), -1, tagDepth)
+	cfg.out.recorders = cfg.out.recorders[:len(cfg.out.recorders)-1]
+	// failed contents are built again on the next builds
+	if err != nil {
+		return cfg.out.since(start), err
+	}
+
+	cfg.cache.Set(node.key, encodeCached(rec, indent), node.ttl)
+	return cfg.out.since(start), nil
+}
+
+// writeCached writes the stored bytes, indenting the line breaks.
+func (cfg *buildConfig) writeCached(breaks []int, body []byte, indent int) error {
+	out := cfg.out
+	prev := 0
+	for _, off := range breaks {
+		out.Write(body[prev:off])
+		out.markBreak()
+		if err := writeIndent(out, indent); err != nil {
+			return err
+		}
+		prev = off + 1
+	}
+	_, err := out.Write(body[prev:])
+	return err
+}
+
+// encodeCached stores the recorded bytes as they would be written at the
+// root, without the indentation of their depth, after the offsets of the
+// line breaks.
+func encodeCached(rec *recorder, indent int) []byte {
+	body := rec.buf.Bytes()
+	breaks := make([]int, len(rec.breaks))
+	trimmed := make([]byte, 0, len(body))
+	prev := 0
+	for i, off := range rec.breaks {
+		trimmed = append(trimmed, body[prev:off+1]...)
+		breaks[i] = len(trimmed) - 1
+		prev = off + 1
+		for n := 0; n < indent && prev < len(body) && body[prev] == ' '; n++ {
+			prev++
+		}
+	}
+	trimmed = append(trimmed, body[prev:]...)
+
+	b := binary.AppendUvarint(nil, uint64(len(breaks)))
+	for _, off := range breaks {
+		b = binary.AppendUvarint(b, uint64(off))
+	}
+	return append(b, trimmed...)
+}
+
+// decodeCached splits the bytes stored by encodeCached.
+func decodeCached(b []byte) (breaks []int, body []byte, ok bool) {
+	n, size := binary.Uvarint(b)
+	if size <= 0 || n > uint64(len(b)) {
+		return nil, nil, false
+	}
+	b = b[size:]
+	breaks = make([]int, n)
+	for i := range breaks {
+		off, size := binary.Uvarint(b)
+		if size <= 0 {
+			return nil, nil, false
+		}
+		breaks[i], b = int(off), b[size:]
+	}
+	for i, off := range breaks {
+		if off >= len(b) || b[off] != '\n' || i > 0 && off <= breaks[i-1] {
+			return nil, nil, false
+		}
+	}
+	return breaks, b, true
+}
+
+// CacheStats holds the counters of a MemoryCache.
+type CacheStats struct {
+	Hits   int
+	Misses int
+	// entries removed to make room for new ones
+	Evictions int
+	Entries   int
+}
+
+// MemoryCache is an in-memory Cache holding up to a max number of
+// entries, evicting the least recently used ones.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	// most recently used first
+	lru     *list.List
+	entries map[string]*list.Element
+	stats   CacheStats
+	now     func() time.Time
+}
+
+type memoryCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCache builds a cache holding up to maxEntries entries, at least
+// one.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: max(maxEntries, 1),
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if ok && c.isExpired(el.Value.(*memoryCacheEntry)) {
+		c.remove(el)
+		ok = false
+	}
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+
+	c.stats.Hits++
+	c.lru.MoveToFront(el)
+	return el.Value.(*memoryCacheEntry).value, true
+}
+
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &memoryCacheEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expiresAt = c.now().Add(ttl)
+	}
+
+	if el, ok := c.entries[key]; ok {
+		el.Value = entry
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+}
+
+// Clear invalidates all the entries, keeping the counters.
+func (c *MemoryCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lru.Init()
+	clear(c.entries)
+}
+
+// Stats returns the counters since the cache was created.
+func (c *MemoryCache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+func (c *MemoryCache) isExpired(entry *memoryCacheEntry) bool {
+	return !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt)
+}
+
+func (c *MemoryCache) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*memoryCacheEntry).key)
+}
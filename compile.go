@@ -0,0 +1,204 @@
+package go_ml
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+type compiledNode struct {
+	// the compiled content, walked by validations and lazy prefetches
+	source HTMLContent
+	parts  []compiledPart
+	// metrics and ids of the static elements, added on each build
+	elements int
+	maxDepth int
+	ids      []compiledID
+	// the static parts are indented (or minified) for this config, so the
+	// holes are too
+	indentation struct {
+		isEnable         bool
+		indentationLevel uint8
+	}
+	minify bool
+}
+
+type compiledPart struct {
+	static string
+	// dynamic content built on each build, nil for static parts
+	hole *compiledHole
+	// state of the pretty-printer after the static part or before the hole
+	pretty prettyState
+}
+
+type compiledHole struct {
+	content HTMLContent
+	index   int
+	// depth and path of the hole from the compiled root
+	tagDepth int
+	path     elementPath
+}
+
+type compiledID struct {
+	id   string
+	path string
+}
+
+// compiler collects the parts of a content being compiled.
+type compiler struct {
+	node *compiledNode
+	buf  strings.Builder
+}
+
+const compiledPathName = "#compiled"
+
+// Compile writes the content once, so each build writes the resulting
+// bytes instead of walking it again, i.g.:
+//
+//	var SiteLayout = NewLayout("Site", MustCompile(Html()(
+//		Head()(Script(Src(htmxCDN))()),
+//		Body()(Yield("main")),
+//	), WithDefaultIndentation()))
+//
+// The dynamic contents (components, layouts, slots, providers, consumers,
+// async, lazy and cached contents) are left as holes, built on each build
+// along with the flush points. Only the elements and texts are compiled.
+//
+// The config is fixed at compile time: the static parts are written with
+// the compile options (indentation, minify, URL policy, flush after...)
+// and the indentation assumes the content is the root of the document, so
+// it's also used for the holes. The writer option is ignored.
+func Compile(root HTMLContent, opts ...buildOpt) (HTMLContent, error) {
+	node := &compiledNode{source: root}
+	cp := &compiler{node: node}
+
+	cfg := &buildConfig{ctx: context.Background(), result: new(RenderResult), compiling: cp}
+	cfg.debug.logger = nopLogger
+	cfg.urlPolicy = DefaultURLPolicy
+	cfg.pretty = prettyState{block: true}
+	for _, op := range opts {
+		op(cfg)
+	}
+	// ids are always collected, so the builds can track them
+	if cfg.ids == nil {
+		cfg.ids = make(map[string]string)
+	}
+
+	if cfg.validate {
+		if err := Validate(root); err != nil {
+			return HTMLContent{}, err
+		}
+	}
+
+	cfg.stdWriter = &cp.buf
+	cfg.out = newRenderWriter(cfg.stdWriter)
+	_, err := cfg.parseContent(root, -1, 1)
+	if flushErr := cfg.out.release(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		return HTMLContent{}, err
+	}
+	cp.addStatic(cfg.pretty)
+
+	node.elements = cfg.result.Elements
+	node.maxDepth = cfg.result.MaxDepth
+	node.indentation = cfg.indentitation
+	node.minify = cfg.minify
+	return HTMLContent{compiled: node, ctType: CompiledContent}, nil
+}
+
+// MustCompile is like Compile but panics if the content can't be compiled.
+// It simplifies the initialization of global variables holding compiled
+// contents.
+func MustCompile(root HTMLContent, opts ...buildOpt) HTMLContent {
+	ct, err := Compile(root, opts...)
+	if err != nil {
+		panic("go_ml: Compile: " + err.Error())
+	}
+	return ct
+}
+
+// isDynamic reports if the content must be left as a hole when compiling.
+func isDynamic(ctType ContentType) bool {
+	switch ctType {
+	case ComponentContent, LayoutContent, SlotContent, ProviderContent, ConsumerContent, AsyncContent, LazyContent, CompiledContent, CachedContent:
+		return true
+	}
+	return false
+}
+
+// addStatic closes the static part written so far.
+func (cp *compiler) addStatic(pretty prettyState) {
+	if cp.buf.Len() == 0 {
+		return
+	}
+	cp.node.parts = append(cp.node.parts, compiledPart{static: cp.buf.String(), pretty: pretty})
+	cp.buf.Reset()
+}
+
+func (cfg *buildConfig) addHole(ct HTMLContent, index int, tagDepth int) error {
+	if err := cfg.out.flush(); err != nil {
+		return err
+	}
+	cfg.compiling.addStatic(cfg.pretty)
+	cfg.compiling.node.parts = append(cfg.compiling.node.parts, compiledPart{
+		hole: &compiledHole{
+			content:  ct,
+			index:    index,
+			tagDepth: tagDepth,
+			path:     slices.Clone(cfg.path),
+		},
+		pretty: cfg.pretty,
+	})
+	return nil
+}
+
+// parseCompiled writes the static parts and builds the holes between them.
+func (cfg *buildConfig) parseCompiled(node *compiledNode, tagDepth int) (int, error) {
+	if err := cfg.ctx.Err(); err != nil {
+		return 0, cfg.buildErr(err)
+	}
+
+	cfg.result.Elements += node.elements
+	if node.maxDepth > 0 {
+		cfg.result.MaxDepth = max(cfg.result.MaxDepth, tagDepth-1+node.maxDepth)
+	}
+
+	if cfg.ids != nil {
+		base := cfg.path.String()
+		for _, id := range node.ids {
+			if err := cfg.trackIDAt(id.id, base+" > "+id.path); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	outerIndentation, outerMinify := cfg.indentitation, cfg.minify
+	cfg.indentitation, cfg.minify = node.indentation, node.minify
+	defer func() { cfg.indentitation, cfg.minify = outerIndentation, outerMinify }()
+
+	out := cfg.out
+	start := out.written
+	for _, part := range node.parts {
+		// the holes are placed as they would be without compiling
+		if part.hole == nil {
+			if _, err := out.WriteString(part.static); err != nil {
+				return out.since(start), err
+			}
+			cfg.pretty = part.pretty
+			continue
+		}
+		cfg.pretty = part.pretty
+
+		hole := part.hole
+		outerPath := cfg.path
+		cfg.path = append(cfg.path, hole.path...)
+		_, err := cfg.parseContent(hole.content, hole.index, tagDepth-1+hole.tagDepth)
+		cfg.path = outerPath
+		if err != nil {
+			return out.since(start), err
+		}
+	}
+	return out.since(start), nil
+}
@@ -0,0 +1,86 @@
+package go_ml
+
+import "reflect"
+
+// Component is a reusable piece of the tree. Unlike plain functions
+// returning HTMLContent, components are rendered by the builder, so their
+// names show up on debug logs and error paths.
+//
+// Use a component anywhere an HTMLContent is accepted:
+//
+//	Tbody()(Use(TodoRow{Todo: t}))
+type Component interface {
+	Render() HTMLContent
+}
+
+// NamedComponent lets a component choose its name, otherwise the name of
+// its type is used.
+type NamedComponent interface {
+	Component
+	ComponentName() string
+}
+
+// Use turns the component into content.
+func Use(c Component) HTMLContent {
+	return HTMLContent{component: c, ctType: ComponentContent}
+}
+
+type funcComponent[P any] struct {
+	name     string
+	render   func(props P, children ...HTMLContent) HTMLContent
+	props    P
+	children []HTMLContent
+}
+
+func (c funcComponent[P]) Render() HTMLContent {
+	return c.render(c.props, c.children...)
+}
+
+func (c funcComponent[P]) ComponentName() string {
+	return c.name
+}
+
+func (c funcComponent[P]) canRender() bool {
+	return c.render != nil
+}
+
+// NewComponent builds a named component from a render function taking
+// props and children, i.g.:
+//
+//	var Card = NewComponent("Card", func(title string, children ...HTMLContent) HTMLContent {
+//		return Div(ClassNames("card"))(H2()(Text(title)), Fragment(children...))
+//	})
+//
+//	Card("Todo List", ListOfTodos(todos...))
+func NewComponent[P any](name string, render func(props P, children ...HTMLContent) HTMLContent) func(props P, children ...HTMLContent) HTMLContent {
+	return func(props P, children ...HTMLContent) HTMLContent {
+		return Use(funcComponent[P]{name: name, render: render, props: props, children: children})
+	}
+}
+
+// canRender reports if the component has something to render, which isn't
+// the case of the ones built by NewComponent with a nil function.
+func canRender(c Component) bool {
+	fc, ok := c.(interface{ canRender() bool })
+	return !ok || fc.canRender()
+}
+
+func componentName(c Component) string {
+	if named, ok := c.(NamedComponent); ok {
+		return named.ComponentName()
+	}
+
+	t := reflect.TypeOf(c)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Name() == "" {
+		return "anonymous"
+	}
+	return t.Name()
+}
+
+// components are prefixed on the path to tell them apart from tags
+func componentPathName(c Component) string {
+	return "@" + componentName(c)
+}
@@ -0,0 +1,42 @@
+package go_ml
+
+import "context"
+
+type providerNode struct {
+	key      any
+	value    any
+	contents []HTMLContent
+}
+
+func (p *providerNode) provide(ctx context.Context) context.Context {
+	return context.WithValue(ctx, p.key, p.value)
+}
+
+const (
+	providerPathName = "#provider"
+	consumerPathName = "#consumer"
+)
+
+// Provide makes the value available to every Consume content below it,
+// without threading it through the components, i.g.: the current user,
+// the locale or a CSRF token. The key follows the context.WithValue rules.
+func Provide(key, value any, contents ...HTMLContent) HTMLContent {
+	return HTMLContent{
+		provider: &providerNode{key: key, value: value, contents: contents},
+		ctType:   ProviderContent,
+	}
+}
+
+// Consume computes the content while building, from the context given to
+// BuildDOMContext plus the values of the Provide contents above it, i.g.:
+//
+//	Consume(func(ctx context.Context) HTMLContent {
+//		user, _ := ctx.Value(userKey{}).(User)
+//		return Span()(Text(user.Name))
+//	})
+//
+// The function is called on every build (and on validation as well), so it
+// shouldn't have side effects.
+func Consume(fn func(ctx context.Context) HTMLContent) HTMLContent {
+	return HTMLContent{consumer: fn, ctType: ConsumerContent}
+}
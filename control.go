@@ -0,0 +1,55 @@
+package go_ml
+
+/* Control flow helpers */
+
+// If renders the content only when the condition is true.
+func If(cond bool, content HTMLContent) HTMLContent {
+	if cond {
+		return content
+	}
+	return Nothing()
+}
+
+// IfElse renders the first content when the condition is true, otherwise
+// the second one.
+func IfElse(cond bool, content, elseContent HTMLContent) HTMLContent {
+	if cond {
+		return content
+	}
+	return elseContent
+}
+
+// Map renders each item of the slice, in order, without a wrapper tag.
+func Map[T any](items []T, fn func(item T) HTMLContent) HTMLContent {
+	contents := make([]HTMLContent, 0, len(items))
+	for _, item := range items {
+		contents = append(contents, fn(item))
+	}
+	return Fragment(contents...)
+}
+
+// Range works like Map, also passing the index of the item.
+func Range[T any](items []T, fn func(i int, item T) HTMLContent) HTMLContent {
+	contents := make([]HTMLContent, 0, len(items))
+	for i, item := range items {
+		contents = append(contents, fn(i, item))
+	}
+	return Fragment(contents...)
+}
+
+// IfAttr keeps the attribute only when the condition is true.
+func IfAttr(cond bool, attr HTMLAttribute) HTMLAttribute {
+	if cond {
+		return attr
+	}
+	return NothingAttr()
+}
+
+// IfElseAttr keeps the first attribute when the condition is true,
+// otherwise the second one.
+func IfElseAttr(cond bool, attr, elseAttr HTMLAttribute) HTMLAttribute {
+	if cond {
+		return attr
+	}
+	return elseAttr
+}
@@ -0,0 +1,691 @@
+// Code generated by go run ./gen; DO NOT EDIT.
+
+package go_ml
+
+// A builds the <a> element.
+func A(attrs ...HTMLAttribute) tagClosure {
+	return Tag("a", NonVoid, attrs...)
+}
+
+// Abbr builds the <abbr> element.
+func Abbr(attrs ...HTMLAttribute) tagClosure {
+	return Tag("abbr", NonVoid, attrs...)
+}
+
+// Address builds the <address> element.
+func Address(attrs ...HTMLAttribute) tagClosure {
+	return Tag("address", NonVoid, attrs...)
+}
+
+// Area builds the void <area> element.
+func Area(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("area", Void, attrs...)()
+}
+
+// Article builds the <article> element.
+func Article(attrs ...HTMLAttribute) tagClosure {
+	return Tag("article", NonVoid, attrs...)
+}
+
+// Aside builds the <aside> element.
+func Aside(attrs ...HTMLAttribute) tagClosure {
+	return Tag("aside", NonVoid, attrs...)
+}
+
+// Audio builds the <audio> element.
+func Audio(attrs ...HTMLAttribute) tagClosure {
+	return Tag("audio", NonVoid, attrs...)
+}
+
+// B builds the <b> element.
+func B(attrs ...HTMLAttribute) tagClosure {
+	return Tag("b", NonVoid, attrs...)
+}
+
+// Base builds the void <base> element.
+func Base(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("base", Void, attrs...)()
+}
+
+// Bdi builds the <bdi> element.
+func Bdi(attrs ...HTMLAttribute) tagClosure {
+	return Tag("bdi", NonVoid, attrs...)
+}
+
+// Bdo builds the <bdo> element.
+func Bdo(attrs ...HTMLAttribute) tagClosure {
+	return Tag("bdo", NonVoid, attrs...)
+}
+
+// Blockquote builds the <blockquote> element.
+func Blockquote(attrs ...HTMLAttribute) tagClosure {
+	return Tag("blockquote", NonVoid, attrs...)
+}
+
+// Body builds the <body> element.
+func Body(attrs ...HTMLAttribute) tagClosure {
+	return Tag("body", NonVoid, attrs...)
+}
+
+// Br builds the void <br> element.
+func Br(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("br", Void, attrs...)()
+}
+
+// Button builds the <button> element.
+func Button(attrs ...HTMLAttribute) tagClosure {
+	return Tag("button", NonVoid, attrs...)
+}
+
+// Canvas builds the <canvas> element.
+func Canvas(attrs ...HTMLAttribute) tagClosure {
+	return Tag("canvas", NonVoid, attrs...)
+}
+
+// Caption builds the <caption> element.
+func Caption(attrs ...HTMLAttribute) tagClosure {
+	return Tag("caption", NonVoid, attrs...)
+}
+
+// Cite builds the <cite> element.
+func Cite(attrs ...HTMLAttribute) tagClosure {
+	return Tag("cite", NonVoid, attrs...)
+}
+
+// Code builds the <code> element.
+func Code(attrs ...HTMLAttribute) tagClosure {
+	return Tag("code", NonVoid, attrs...)
+}
+
+// Col builds the void <col> element.
+func Col(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("col", Void, attrs...)()
+}
+
+// Colgroup builds the <colgroup> element.
+func Colgroup(attrs ...HTMLAttribute) tagClosure {
+	return Tag("colgroup", NonVoid, attrs...)
+}
+
+// Data builds the <data> element.
+func Data(attrs ...HTMLAttribute) tagClosure {
+	return Tag("data", NonVoid, attrs...)
+}
+
+// Datalist builds the <datalist> element.
+func Datalist(attrs ...HTMLAttribute) tagClosure {
+	return Tag("datalist", NonVoid, attrs...)
+}
+
+// Dd builds the <dd> element.
+func Dd(attrs ...HTMLAttribute) tagClosure {
+	return Tag("dd", NonVoid, attrs...)
+}
+
+// Del builds the <del> element.
+func Del(attrs ...HTMLAttribute) tagClosure {
+	return Tag("del", NonVoid, attrs...)
+}
+
+// Details builds the <details> element.
+func Details(attrs ...HTMLAttribute) tagClosure {
+	return Tag("details", NonVoid, attrs...)
+}
+
+// Dfn builds the <dfn> element.
+func Dfn(attrs ...HTMLAttribute) tagClosure {
+	return Tag("dfn", NonVoid, attrs...)
+}
+
+// Dialog builds the <dialog> element.
+func Dialog(attrs ...HTMLAttribute) tagClosure {
+	return Tag("dialog", NonVoid, attrs...)
+}
+
+// Div builds the <div> element.
+func Div(attrs ...HTMLAttribute) tagClosure {
+	return Tag("div", NonVoid, attrs...)
+}
+
+// Dl builds the <dl> element.
+func Dl(attrs ...HTMLAttribute) tagClosure {
+	return Tag("dl", NonVoid, attrs...)
+}
+
+// Dt builds the <dt> element.
+func Dt(attrs ...HTMLAttribute) tagClosure {
+	return Tag("dt", NonVoid, attrs...)
+}
+
+// Em builds the <em> element.
+func Em(attrs ...HTMLAttribute) tagClosure {
+	return Tag("em", NonVoid, attrs...)
+}
+
+// Embed builds the void <embed> element.
+func Embed(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("embed", Void, attrs...)()
+}
+
+// Fieldset builds the <fieldset> element.
+func Fieldset(attrs ...HTMLAttribute) tagClosure {
+	return Tag("fieldset", NonVoid, attrs...)
+}
+
+// Figcaption builds the <figcaption> element.
+func Figcaption(attrs ...HTMLAttribute) tagClosure {
+	return Tag("figcaption", NonVoid, attrs...)
+}
+
+// Figure builds the <figure> element.
+func Figure(attrs ...HTMLAttribute) tagClosure {
+	return Tag("figure", NonVoid, attrs...)
+}
+
+// Footer builds the <footer> element.
+func Footer(attrs ...HTMLAttribute) tagClosure {
+	return Tag("footer", NonVoid, attrs...)
+}
+
+// Form builds the <form> element.
+func Form(attrs ...HTMLAttribute) tagClosure {
+	return Tag("form", NonVoid, attrs...)
+}
+
+// H1 builds the <h1> element.
+func H1(attrs ...HTMLAttribute) tagClosure {
+	return Tag("h1", NonVoid, attrs...)
+}
+
+// H2 builds the <h2> element.
+func H2(attrs ...HTMLAttribute) tagClosure {
+	return Tag("h2", NonVoid, attrs...)
+}
+
+// H3 builds the <h3> element.
+func H3(attrs ...HTMLAttribute) tagClosure {
+	return Tag("h3", NonVoid, attrs...)
+}
+
+// H4 builds the <h4> element.
+func H4(attrs ...HTMLAttribute) tagClosure {
+	return Tag("h4", NonVoid, attrs...)
+}
+
+// H5 builds the <h5> element.
+func H5(attrs ...HTMLAttribute) tagClosure {
+	return Tag("h5", NonVoid, attrs...)
+}
+
+// H6 builds the <h6> element.
+func H6(attrs ...HTMLAttribute) tagClosure {
+	return Tag("h6", NonVoid, attrs...)
+}
+
+// Head builds the <head> element.
+func Head(attrs ...HTMLAttribute) tagClosure {
+	return Tag("head", NonVoid, attrs...)
+}
+
+// Header builds the <header> element.
+func Header(attrs ...HTMLAttribute) tagClosure {
+	return Tag("header", NonVoid, attrs...)
+}
+
+// Hgroup builds the <hgroup> element.
+func Hgroup(attrs ...HTMLAttribute) tagClosure {
+	return Tag("hgroup", NonVoid, attrs...)
+}
+
+// Hr builds the void <hr> element.
+func Hr(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("hr", Void, attrs...)()
+}
+
+// Html builds the <html> element.
+func Html(attrs ...HTMLAttribute) tagClosure {
+	return Tag("html", NonVoid, attrs...)
+}
+
+// I builds the <i> element.
+func I(attrs ...HTMLAttribute) tagClosure {
+	return Tag("i", NonVoid, attrs...)
+}
+
+// Iframe builds the <iframe> element.
+func Iframe(attrs ...HTMLAttribute) tagClosure {
+	return Tag("iframe", NonVoid, attrs...)
+}
+
+// Img builds the void <img> element.
+func Img(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("img", Void, attrs...)()
+}
+
+// Input builds the void <input> element.
+func Input(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("input", Void, attrs...)()
+}
+
+// Ins builds the <ins> element.
+func Ins(attrs ...HTMLAttribute) tagClosure {
+	return Tag("ins", NonVoid, attrs...)
+}
+
+// Kbd builds the <kbd> element.
+func Kbd(attrs ...HTMLAttribute) tagClosure {
+	return Tag("kbd", NonVoid, attrs...)
+}
+
+// Label builds the <label> element.
+func Label(attrs ...HTMLAttribute) tagClosure {
+	return Tag("label", NonVoid, attrs...)
+}
+
+// Legend builds the <legend> element.
+func Legend(attrs ...HTMLAttribute) tagClosure {
+	return Tag("legend", NonVoid, attrs...)
+}
+
+// Li builds the <li> element.
+func Li(attrs ...HTMLAttribute) tagClosure {
+	return Tag("li", NonVoid, attrs...)
+}
+
+// Link builds the void <link> element.
+func Link(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("link", Void, attrs...)()
+}
+
+// Main builds the <main> element.
+func Main(attrs ...HTMLAttribute) tagClosure {
+	return Tag("main", NonVoid, attrs...)
+}
+
+// MapTag builds the <map> element.
+func MapTag(attrs ...HTMLAttribute) tagClosure {
+	return Tag("map", NonVoid, attrs...)
+}
+
+// Mark builds the <mark> element.
+func Mark(attrs ...HTMLAttribute) tagClosure {
+	return Tag("mark", NonVoid, attrs...)
+}
+
+// Math builds the <math> element.
+func Math(attrs ...HTMLAttribute) tagClosure {
+	return Tag("math", NonVoid, attrs...)
+}
+
+// Menu builds the <menu> element.
+func Menu(attrs ...HTMLAttribute) tagClosure {
+	return Tag("menu", NonVoid, attrs...)
+}
+
+// Meta builds the void <meta> element.
+func Meta(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("meta", Void, attrs...)()
+}
+
+// Meter builds the <meter> element.
+func Meter(attrs ...HTMLAttribute) tagClosure {
+	return Tag("meter", NonVoid, attrs...)
+}
+
+// Nav builds the <nav> element.
+func Nav(attrs ...HTMLAttribute) tagClosure {
+	return Tag("nav", NonVoid, attrs...)
+}
+
+// Noscript builds the <noscript> element.
+func Noscript(attrs ...HTMLAttribute) tagClosure {
+	return Tag("noscript", NonVoid, attrs...)
+}
+
+// Object builds the <object> element.
+func Object(attrs ...HTMLAttribute) tagClosure {
+	return Tag("object", NonVoid, attrs...)
+}
+
+// Ol builds the <ol> element.
+func Ol(attrs ...HTMLAttribute) tagClosure {
+	return Tag("ol", NonVoid, attrs...)
+}
+
+// Optgroup builds the <optgroup> element.
+func Optgroup(attrs ...HTMLAttribute) tagClosure {
+	return Tag("optgroup", NonVoid, attrs...)
+}
+
+// Option builds the <option> element.
+func Option(attrs ...HTMLAttribute) tagClosure {
+	return Tag("option", NonVoid, attrs...)
+}
+
+// Output builds the <output> element.
+func Output(attrs ...HTMLAttribute) tagClosure {
+	return Tag("output", NonVoid, attrs...)
+}
+
+// P builds the <p> element.
+func P(attrs ...HTMLAttribute) tagClosure {
+	return Tag("p", NonVoid, attrs...)
+}
+
+// Picture builds the <picture> element.
+func Picture(attrs ...HTMLAttribute) tagClosure {
+	return Tag("picture", NonVoid, attrs...)
+}
+
+// Pre builds the <pre> element.
+func Pre(attrs ...HTMLAttribute) tagClosure {
+	return Tag("pre", NonVoid, attrs...)
+}
+
+// Progress builds the <progress> element.
+func Progress(attrs ...HTMLAttribute) tagClosure {
+	return Tag("progress", NonVoid, attrs...)
+}
+
+// Q builds the <q> element.
+func Q(attrs ...HTMLAttribute) tagClosure {
+	return Tag("q", NonVoid, attrs...)
+}
+
+// Rp builds the <rp> element.
+func Rp(attrs ...HTMLAttribute) tagClosure {
+	return Tag("rp", NonVoid, attrs...)
+}
+
+// Rt builds the <rt> element.
+func Rt(attrs ...HTMLAttribute) tagClosure {
+	return Tag("rt", NonVoid, attrs...)
+}
+
+// Ruby builds the <ruby> element.
+func Ruby(attrs ...HTMLAttribute) tagClosure {
+	return Tag("ruby", NonVoid, attrs...)
+}
+
+// S builds the <s> element.
+func S(attrs ...HTMLAttribute) tagClosure {
+	return Tag("s", NonVoid, attrs...)
+}
+
+// Samp builds the <samp> element.
+func Samp(attrs ...HTMLAttribute) tagClosure {
+	return Tag("samp", NonVoid, attrs...)
+}
+
+// Script builds the <script> element.
+func Script(attrs ...HTMLAttribute) tagClosure {
+	return Tag("script", NonVoid, attrs...)
+}
+
+// Search builds the <search> element.
+func Search(attrs ...HTMLAttribute) tagClosure {
+	return Tag("search", NonVoid, attrs...)
+}
+
+// Section builds the <section> element.
+func Section(attrs ...HTMLAttribute) tagClosure {
+	return Tag("section", NonVoid, attrs...)
+}
+
+// Select builds the <select> element.
+func Select(attrs ...HTMLAttribute) tagClosure {
+	return Tag("select", NonVoid, attrs...)
+}
+
+// Slot builds the <slot> element.
+func Slot(attrs ...HTMLAttribute) tagClosure {
+	return Tag("slot", NonVoid, attrs...)
+}
+
+// Small builds the <small> element.
+func Small(attrs ...HTMLAttribute) tagClosure {
+	return Tag("small", NonVoid, attrs...)
+}
+
+// Source builds the void <source> element.
+func Source(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("source", Void, attrs...)()
+}
+
+// Span builds the <span> element.
+func Span(attrs ...HTMLAttribute) tagClosure {
+	return Tag("span", NonVoid, attrs...)
+}
+
+// Strong builds the <strong> element.
+func Strong(attrs ...HTMLAttribute) tagClosure {
+	return Tag("strong", NonVoid, attrs...)
+}
+
+// Style builds the <style> element.
+func Style(attrs ...HTMLAttribute) tagClosure {
+	return Tag("style", NonVoid, attrs...)
+}
+
+// Sub builds the <sub> element.
+func Sub(attrs ...HTMLAttribute) tagClosure {
+	return Tag("sub", NonVoid, attrs...)
+}
+
+// Summary builds the <summary> element.
+func Summary(attrs ...HTMLAttribute) tagClosure {
+	return Tag("summary", NonVoid, attrs...)
+}
+
+// Sup builds the <sup> element.
+func Sup(attrs ...HTMLAttribute) tagClosure {
+	return Tag("sup", NonVoid, attrs...)
+}
+
+// Svg builds the <svg> element.
+func Svg(attrs ...HTMLAttribute) tagClosure {
+	return Tag("svg", NonVoid, attrs...)
+}
+
+// Table builds the <table> element.
+func Table(attrs ...HTMLAttribute) tagClosure {
+	return Tag("table", NonVoid, attrs...)
+}
+
+// Tbody builds the <tbody> element.
+func Tbody(attrs ...HTMLAttribute) tagClosure {
+	return Tag("tbody", NonVoid, attrs...)
+}
+
+// Td builds the <td> element.
+func Td(attrs ...HTMLAttribute) tagClosure {
+	return Tag("td", NonVoid, attrs...)
+}
+
+// Template builds the <template> element.
+func Template(attrs ...HTMLAttribute) tagClosure {
+	return Tag("template", NonVoid, attrs...)
+}
+
+// Textarea builds the <textarea> element.
+func Textarea(attrs ...HTMLAttribute) tagClosure {
+	return Tag("textarea", NonVoid, attrs...)
+}
+
+// Tfoot builds the <tfoot> element.
+func Tfoot(attrs ...HTMLAttribute) tagClosure {
+	return Tag("tfoot", NonVoid, attrs...)
+}
+
+// Th builds the <th> element.
+func Th(attrs ...HTMLAttribute) tagClosure {
+	return Tag("th", NonVoid, attrs...)
+}
+
+// Thead builds the <thead> element.
+func Thead(attrs ...HTMLAttribute) tagClosure {
+	return Tag("thead", NonVoid, attrs...)
+}
+
+// Time builds the <time> element.
+func Time(attrs ...HTMLAttribute) tagClosure {
+	return Tag("time", NonVoid, attrs...)
+}
+
+// Title builds the <title> element.
+func Title(attrs ...HTMLAttribute) tagClosure {
+	return Tag("title", NonVoid, attrs...)
+}
+
+// Tr builds the <tr> element.
+func Tr(attrs ...HTMLAttribute) tagClosure {
+	return Tag("tr", NonVoid, attrs...)
+}
+
+// Track builds the void <track> element.
+func Track(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("track", Void, attrs...)()
+}
+
+// U builds the <u> element.
+func U(attrs ...HTMLAttribute) tagClosure {
+	return Tag("u", NonVoid, attrs...)
+}
+
+// Ul builds the <ul> element.
+func Ul(attrs ...HTMLAttribute) tagClosure {
+	return Tag("ul", NonVoid, attrs...)
+}
+
+// Var builds the <var> element.
+func Var(attrs ...HTMLAttribute) tagClosure {
+	return Tag("var", NonVoid, attrs...)
+}
+
+// Video builds the <video> element.
+func Video(attrs ...HTMLAttribute) tagClosure {
+	return Tag("video", NonVoid, attrs...)
+}
+
+// Wbr builds the void <wbr> element.
+func Wbr(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("wbr", Void, attrs...)()
+}
+
+// content categories of each element, used by the content model validator
+var elementCategories = map[string]contentCategory{
+	"a":          flowContent | phrasingContent | interactiveContent,
+	"abbr":       flowContent | phrasingContent,
+	"address":    flowContent,
+	"area":       flowContent | phrasingContent,
+	"article":    flowContent | sectioningContent,
+	"aside":      flowContent | sectioningContent,
+	"audio":      flowContent | phrasingContent | embeddedContent | interactiveContent,
+	"b":          flowContent | phrasingContent,
+	"base":       metadataContent,
+	"bdi":        flowContent | phrasingContent,
+	"bdo":        flowContent | phrasingContent,
+	"blockquote": flowContent,
+	"body":       0,
+	"br":         flowContent | phrasingContent,
+	"button":     flowContent | phrasingContent | interactiveContent,
+	"canvas":     flowContent | phrasingContent | embeddedContent,
+	"caption":    0,
+	"cite":       flowContent | phrasingContent,
+	"code":       flowContent | phrasingContent,
+	"col":        0,
+	"colgroup":   0,
+	"data":       flowContent | phrasingContent,
+	"datalist":   flowContent | phrasingContent,
+	"dd":         0,
+	"del":        flowContent | phrasingContent,
+	"details":    flowContent | interactiveContent,
+	"dfn":        flowContent | phrasingContent,
+	"dialog":     flowContent,
+	"div":        flowContent,
+	"dl":         flowContent,
+	"dt":         0,
+	"em":         flowContent | phrasingContent,
+	"embed":      flowContent | phrasingContent | embeddedContent | interactiveContent,
+	"fieldset":   flowContent,
+	"figcaption": 0,
+	"figure":     flowContent,
+	"footer":     flowContent,
+	"form":       flowContent,
+	"h1":         flowContent | headingContent,
+	"h2":         flowContent | headingContent,
+	"h3":         flowContent | headingContent,
+	"h4":         flowContent | headingContent,
+	"h5":         flowContent | headingContent,
+	"h6":         flowContent | headingContent,
+	"head":       0,
+	"header":     flowContent,
+	"hgroup":     flowContent | headingContent,
+	"hr":         flowContent,
+	"html":       0,
+	"i":          flowContent | phrasingContent,
+	"iframe":     flowContent | phrasingContent | embeddedContent | interactiveContent,
+	"img":        flowContent | phrasingContent | embeddedContent | interactiveContent,
+	"input":      flowContent | phrasingContent | interactiveContent,
+	"ins":        flowContent | phrasingContent,
+	"kbd":        flowContent | phrasingContent,
+	"label":      flowContent | phrasingContent | interactiveContent,
+	"legend":     0,
+	"li":         0,
+	"link":       metadataContent | flowContent | phrasingContent,
+	"main":       flowContent,
+	"map":        flowContent | phrasingContent,
+	"mark":       flowContent | phrasingContent,
+	"math":       flowContent | phrasingContent | embeddedContent,
+	"menu":       flowContent,
+	"meta":       metadataContent | flowContent | phrasingContent,
+	"meter":      flowContent | phrasingContent,
+	"nav":        flowContent | sectioningContent,
+	"noscript":   metadataContent | flowContent | phrasingContent,
+	"object":     flowContent | phrasingContent | embeddedContent,
+	"ol":         flowContent,
+	"optgroup":   0,
+	"option":     0,
+	"output":     flowContent | phrasingContent,
+	"p":          flowContent,
+	"picture":    flowContent | phrasingContent | embeddedContent,
+	"pre":        flowContent,
+	"progress":   flowContent | phrasingContent,
+	"q":          flowContent | phrasingContent,
+	"rp":         0,
+	"rt":         0,
+	"ruby":       flowContent | phrasingContent,
+	"s":          flowContent | phrasingContent,
+	"samp":       flowContent | phrasingContent,
+	"script":     metadataContent | flowContent | phrasingContent | scriptSupportingContent,
+	"search":     flowContent,
+	"section":    flowContent | sectioningContent,
+	"select":     flowContent | phrasingContent | interactiveContent,
+	"slot":       flowContent | phrasingContent,
+	"small":      flowContent | phrasingContent,
+	"source":     0,
+	"span":       flowContent | phrasingContent,
+	"strong":     flowContent | phrasingContent,
+	"style":      metadataContent,
+	"sub":        flowContent | phrasingContent,
+	"summary":    0,
+	"sup":        flowContent | phrasingContent,
+	"svg":        flowContent | phrasingContent | embeddedContent,
+	"table":      flowContent,
+	"tbody":      0,
+	"td":         0,
+	"template":   metadataContent | flowContent | phrasingContent | scriptSupportingContent,
+	"textarea":   flowContent | phrasingContent | interactiveContent,
+	"tfoot":      0,
+	"th":         0,
+	"thead":      0,
+	"time":       flowContent | phrasingContent,
+	"title":      metadataContent,
+	"tr":         0,
+	"track":      0,
+	"u":          flowContent | phrasingContent,
+	"ul":         flowContent,
+	"var":        flowContent | phrasingContent,
+	"video":      flowContent | phrasingContent | embeddedContent | interactiveContent,
+	"wbr":        flowContent | phrasingContent,
+}
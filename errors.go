@@ -0,0 +1,160 @@
+package go_ml
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidAttrName    = errors.New("invalid attribute name")
+	ErrSingleWithValue    = errors.New("single attribute with values")
+	ErrVoidWithChildren   = errors.New("void element with children")
+	ErrInvalidTagName     = errors.New("invalid tag name")
+	ErrUnknownElementType = errors.New("unknown element type")
+	ErrUnknownContentType = errors.New("not recognized content type")
+	ErrNilComponent       = errors.New("nil component")
+	ErrNilFunc            = errors.New("nil content function")
+	ErrCachedAsync        = errors.New("async content inside a cached content")
+)
+
+// BuildError reports which element of the tree failed to build.
+type BuildError struct {
+	// Path from the root to the offending element, where each element is
+	// followed by its index in the parent contents, i.g.:
+	// html > body[1] > div[0] > input[2]
+	// contents grouped by a fragment are prefixed with #fragment[index] and
+	// the ones rendered by a component with @ComponentName[index].
+	Path    string
+	TagName string
+	Err     error
+}
+
+func (e *BuildError) Error() string {
+	return fmt.Sprintf("%s: at [%s]", e.Err, e.Path)
+}
+
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// DuplicateIDError reports an id used by more than one element.
+type DuplicateIDError struct {
+	ID         string
+	FirstPath  string
+	SecondPath string
+}
+
+func (e *DuplicateIDError) Error() string {
+	return fmt.Sprintf("duplicated id [%s]: used at [%s] and [%s]", e.ID, e.FirstPath, e.SecondPath)
+}
+
+func (cfg *buildConfig) trackID(id string) error {
+	path := cfg.path.String()
+	if cfg.compiling != nil {
+		cfg.compiling.node.ids = append(cfg.compiling.node.ids, compiledID{id: id, path: path})
+	}
+	return cfg.trackIDAt(id, path)
+}
+
+func (cfg *buildConfig) trackIDAt(id string, path string) error {
+	firstPath, ok := cfg.ids[id]
+	if !ok {
+		cfg.ids[id] = path
+		return nil
+	}
+
+	cfg.debug.logger.Warn("Duplicated id was written: ",
+		"id", id, "first_path", firstPath, "second_path", path)
+	if cfg.strict {
+		return cfg.buildErr(&DuplicateIDError{ID: id, FirstPath: firstPath, SecondPath: path})
+	}
+	return nil
+}
+
+// fragments have no tag, but are still part of the path
+const fragmentPathName = "#fragment"
+
+type pathEntry struct {
+	tagName string
+	// index in the parent contents, negative for the root element
+	index int
+}
+
+func (p pathEntry) isElement() bool {
+	return p.tagName == "" || p.tagName[0] != '#' && p.tagName[0] != '@'
+}
+
+// elements from the root to the current one
+type elementPath []pathEntry
+
+func (path *elementPath) push(tagName string, index int) {
+	*path = append(*path, pathEntry{tagName: tagName, index: index})
+}
+
+func (path *elementPath) pop() {
+	*path = (*path)[:len(*path)-1]
+}
+
+// hasElements reports if the path holds any tag, besides fragments,
+// components, layouts and slots.
+func (path elementPath) hasElements() bool {
+	for _, p := range path {
+		if p.isElement() {
+			return true
+		}
+	}
+	return false
+}
+
+func (path elementPath) String() string {
+	var sb strings.Builder
+	for i, p := range path {
+		if i > 0 {
+			sb.WriteString(" > ")
+		}
+		sb.WriteString(p.tagName)
+		if p.index >= 0 {
+			fmt.Fprintf(&sb, "[%d]", p.index)
+		}
+	}
+	return sb.String()
+}
+
+// wrap the error with the path. Errors already wrapped by a deeper element
+// are returned as they are.
+func (path elementPath) wrap(err error) error {
+	var bErr *BuildError
+	if errors.As(err, &bErr) {
+		return err
+	}
+
+	var tagName string
+	if len(path) > 0 {
+		tagName = path[len(path)-1].tagName
+	}
+	return &BuildError{Path: path.String(), TagName: tagName, Err: err}
+}
+
+func (cfg *buildConfig) buildErr(err error) error {
+	return cfg.path.wrap(err)
+}
+
+// Tag names must start with an ASCII letter and can't hold characters
+// which would end the tag.
+// Ref: https://html.spec.whatwg.org/multipage/syntax.html#syntax-tag-name
+func isValidTagName(name string) bool {
+	if name == "" {
+		return false
+	}
+	if c := name[0]; !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z') {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r <= ' ', r == '/', r == '>', r == '<', r == '"', r == '\'', r == '=':
+			return false
+		}
+	}
+	return true
+}
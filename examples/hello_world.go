@@ -9,7 +9,7 @@ import (
 func HelloWorldView() go_ml.HTMLContent {
 	return go_ml.Html(go_ml.Lang("en"))(
 		go_ml.Div(go_ml.ClassNames("container"))(
-			go_ml.RawText("Hello World!"),
+			go_ml.Text("Hello World!"),
 		),
 	)
 }
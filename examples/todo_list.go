@@ -18,6 +18,9 @@ var (
 	logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{}))
 
 	db = TodoListDb{storage: make(map[string]TodoList)}
+
+	// rendered rows of the list, invalidated when the todo changes
+	rowCache = ht.NewMemoryCache(1000)
 )
 
 /* Data */
@@ -91,22 +94,32 @@ var (
 func NewButton(innerText string, attrs ...ht.HTMLAttribute) ht.HTMLContent {
 	return ht.Button(
 		append(attrs, DefaultBorder, TodoRowButton)...,
-	)(ht.RawText(innerText))
+	)(ht.Text(innerText))
 }
 
-func PageIndex(partials ...ht.HTMLContent) ht.HTMLContent {
-	return ht.Html()(
+// Pages fill the slots of the site layout, which is compiled once since
+// only the slots change between requests
+var SiteLayout = ht.NewLayout("Site", ht.MustCompile(
+	ht.Html()(
 		ht.Head()(
-			ht.Title()(ht.RawText("Todo List")),
+			ht.Title()(ht.Yield("title", ht.Text("Todo List"))),
 			// tag with custom attributes
 			ht.Script(ht.Src(htmxCDN), ht.Attr("crossorigin", "anonymous"))(),
 			ht.Script(ht.Src(tailwindCDN))(),
+			ht.Yield("head"),
 		),
 		ht.Body(
 			FlexContainerFull,
 			ht.ClassNames("h-100 bg-teal-lightest font-sans"),
-		)(ht.Div()(partials...)),
-	)
+		)(ht.Div()(ht.Yield("main"))),
+	),
+	// same indentation and flush points of the handlers
+	ht.WithDefaultIndentation(),
+	ht.WithFlushAfter("head"),
+))
+
+func PageIndex(partials ...ht.HTMLContent) ht.HTMLContent {
+	return SiteLayout.With(ht.Fill("main", partials...))
 }
 
 func EditTodoRow(t TodoList) ht.HTMLContent {
@@ -132,6 +145,16 @@ func EditTodoRow(t TodoList) ht.HTMLContent {
 }
 
 func LoadTodoRow(t TodoList) ht.HTMLContent {
+	return ht.Use(TodoRow{todo: t})
+}
+
+// Components are named on debug logs and error paths, i.g.: @TodoRow[0]
+type TodoRow struct {
+	todo TodoList
+}
+
+func (row TodoRow) Render() ht.HTMLContent {
+	t := row.todo
 	rowName := "todo-row-" + t.id
 
 	return ht.Tr(ht.Id(rowName), ht.ClassNames("flex justify-stretch"))(
@@ -139,11 +162,11 @@ func LoadTodoRow(t TodoList) ht.HTMLContent {
 			ht.Input(
 				ht.Type("checkbox"),
 				ht.Id(t.id),
-				ht.IsChecked(t.isChecked),
+				ht.IfAttr(t.isChecked, ht.Checked()),
 				ht.HxOn("click",
 					"fetch(`/todo/${this.checked ? 'enable' : 'disable'}/${this.id}`, {method: 'PUT'})")),
 		),
-		ht.Th()(ht.RawText(t.title)),
+		ht.Th()(ht.Text(t.title)),
 		ht.Th()(
 			NewButton("Edit",
 				ht.HxGet("/todo/edit/"+t.id),
@@ -157,19 +180,20 @@ func LoadTodoRow(t TodoList) ht.HTMLContent {
 	)
 }
 
-func ListOfTodos(todos ...TodoList) ht.HTMLContent {
-	if len(todos) == 0 {
-		return ht.Div(ht.Id("todo-list-tb-container"))()
-	}
-
-	var todoLines []ht.HTMLContent
-	for _, t := range todos {
-		todoLines = append(todoLines,
-			ht.Div(ht.ClassNames("w-full"))(LoadTodoRow(t)))
-	}
+// Rows of the list are only rendered again when the todo changes
+func CachedTodoRow(t TodoList) ht.HTMLContent {
+	return ht.Cached("todo-row-"+t.id, time.Hour, func() ht.HTMLContent {
+		return LoadTodoRow(t)
+	})
+}
 
+func ListOfTodos(todos ...TodoList) ht.HTMLContent {
 	return ht.Div(ht.Id("todo-list-tb-container"))(
-		ht.Table(ht.ClassNames("w-full whitespace-nowrap"))(todoLines...),
+		ht.If(len(todos) > 0,
+			ht.Table(ht.ClassNames("w-full whitespace-nowrap"))(
+				ht.Tbody(ht.ClassNames("w-full"))(ht.Map(todos, CachedTodoRow)),
+			),
+		),
 	)
 }
 
@@ -201,6 +225,8 @@ func mainHandler(w http.ResponseWriter, r *http.Request) {
 			ht.WithDefaultIndentation(),
 			ht.WithWriter(w),
 			ht.WithLogger(logger),
+			ht.WithFlushAfter("head"),
+			ht.WithCache(rowCache),
 		)
 		if err != nil {
 			w.Write([]byte(fmt.Sprintf(`{ "message": "%s"}`, err)))
@@ -256,6 +282,7 @@ func mainHandler(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusInternalServerError)
 				break
 			}
+			rowCache.Delete("todo-row-" + curr.id)
 			buildWithOpts(EditTodoRow(curr))
 
 			break
@@ -264,11 +291,13 @@ func mainHandler(w http.ResponseWriter, r *http.Request) {
 			if err := db.Update(pathStrs[3], action == "enable"); err != nil {
 				w.WriteHeader(http.StatusBadRequest)
 			}
+			rowCache.Delete("todo-row-" + pathStrs[3])
 			break
 		}
 		break
 	case http.MethodDelete:
 		db.Delete(pathStrs[2])
+		rowCache.Delete("todo-row-" + pathStrs[2])
 		buildWithOpts(ListOfTodos(db.GetAll()...))
 		break
 	default:
@@ -0,0 +1,216 @@
+// Command gen generates the element and attribute helpers of go_ml from
+// the checked-in tables. Run it through `go generate` from the module root.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+)
+
+const (
+	elementsTable   = "gen/elements.txt"
+	elementsOut     = "elements_gen.go"
+	attributesTable = "gen/attributes.txt"
+	attributesOut   = "attributes_gen.go"
+)
+
+type element struct {
+	TagName    string
+	FnName     string
+	Void       bool
+	Categories string
+}
+
+var categoryNames = map[string]string{
+	"metadata":          "metadataContent",
+	"flow":              "flowContent",
+	"sectioning":        "sectioningContent",
+	"heading":           "headingContent",
+	"phrasing":          "phrasingContent",
+	"embedded":          "embeddedContent",
+	"interactive":       "interactiveContent",
+	"script-supporting": "scriptSupportingContent",
+}
+
+var elementsTmpl = template.Must(template.New("elements").Parse(`// Code generated by go run ./gen; DO NOT EDIT.
+
+package go_ml
+{{range .}}
+{{- if .Void}}
+// {{.FnName}} builds the void <{{.TagName}}> element.
+func {{.FnName}}(attrs ...HTMLAttribute) HTMLContent {
+	return Tag("{{.TagName}}", Void, attrs...)()
+}
+{{else}}
+// {{.FnName}} builds the <{{.TagName}}> element.
+func {{.FnName}}(attrs ...HTMLAttribute) tagClosure {
+	return Tag("{{.TagName}}", NonVoid, attrs...)
+}
+{{end}}
+{{- end}}
+// content categories of each element, used by the content model validator
+var elementCategories = map[string]contentCategory{
+{{- range .}}
+	"{{.TagName}}": {{.Categories}},
+{{- end}}
+}
+`))
+
+type attribute struct {
+	Name     string
+	FnName   string
+	AttrType string
+	Bool     bool
+}
+
+var attributesTmpl = template.Must(template.New("attributes").Parse(`// Code generated by go run ./gen; DO NOT EDIT.
+
+package go_ml
+{{range .}}
+{{- if .Bool}}
+// {{.FnName}} builds the boolean {{.Name}} attribute.
+func {{.FnName}}() HTMLAttribute {
+	return Attr("{{.Name}}", Single)
+}
+{{else}}
+// {{.FnName}} builds the {{.Name}} attribute.
+func {{.FnName}}(values ...string) HTMLAttribute {
+	return Attr("{{.Name}}", {{.AttrType}}, values...)
+}
+{{end}}
+{{- end}}`))
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+
+	if err := genElements(); err != nil {
+		log.Fatal(err)
+	}
+	if err := genAttributes(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func genElements() error {
+	rows, err := readTable(elementsTable)
+	if err != nil {
+		return err
+	}
+
+	var elements []element
+	for _, row := range rows {
+		if len(row.cols) < 3 || len(row.cols) > 4 {
+			return fmt.Errorf("%s:%d: expected 3 or 4 columns, got %d", elementsTable, row.line, len(row.cols))
+		}
+		el := element{TagName: row.cols[0], FnName: exportedName(row.cols[0]), Categories: "0"}
+		switch row.cols[1] {
+		case "void":
+			el.Void = true
+		case "non-void":
+		default:
+			return fmt.Errorf("%s:%d: unknown element kind [%s]", elementsTable, row.line, row.cols[1])
+		}
+		if row.cols[2] != "-" {
+			var consts []string
+			for _, c := range strings.Split(row.cols[2], ",") {
+				name, ok := categoryNames[c]
+				if !ok {
+					return fmt.Errorf("%s:%d: unknown content category [%s]", elementsTable, row.line, c)
+				}
+				consts = append(consts, name)
+			}
+			el.Categories = strings.Join(consts, " | ")
+		}
+		if len(row.cols) == 4 {
+			el.FnName = row.cols[3]
+		}
+		elements = append(elements, el)
+	}
+
+	return writeSource(elementsOut, elementsTmpl, elements)
+}
+
+func genAttributes() error {
+	rows, err := readTable(attributesTable)
+	if err != nil {
+		return err
+	}
+
+	var attrs []attribute
+	for _, row := range rows {
+		if len(row.cols) < 2 || len(row.cols) > 3 {
+			return fmt.Errorf("%s:%d: expected 2 or 3 columns, got %d", attributesTable, row.line, len(row.cols))
+		}
+		attr := attribute{Name: row.cols[0], FnName: exportedName(row.cols[0])}
+		switch row.cols[1] {
+		case "bool":
+			attr.Bool = true
+			attr.AttrType = "Single"
+		case "string":
+			attr.AttrType = "DoubleQuoted"
+		case "url":
+			attr.AttrType = "URL"
+		default:
+			return fmt.Errorf("%s:%d: unknown attribute kind [%s]", attributesTable, row.line, row.cols[1])
+		}
+		if len(row.cols) == 3 {
+			attr.FnName = row.cols[2]
+		}
+		attrs = append(attrs, attr)
+	}
+
+	return writeSource(attributesOut, attributesTmpl, attrs)
+}
+
+type tableRow struct {
+	line int
+	cols []string
+}
+
+// readTable reads a whitespace separated table, skipping blank lines
+// and '#' comments.
+func readTable(path string) ([]tableRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rows []tableRow
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		text, _, _ := strings.Cut(sc.Text(), "#")
+		if cols := strings.Fields(text); len(cols) > 0 {
+			rows = append(rows, tableRow{line: line, cols: cols})
+		}
+	}
+	return rows, sc.Err()
+}
+
+func writeSource(path string, tmpl *template.Template, data any) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("formatting %s: %w", path, err)
+	}
+	return os.WriteFile(path, src, 0o644)
+}
+
+// exportedName turns names like "accept-charset" into "AcceptCharset".
+func exportedName(name string) string {
+	var sb strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == ':' }) {
+		sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return sb.String()
+}
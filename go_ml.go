@@ -1,11 +1,15 @@
 package go_ml
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 var (
@@ -23,8 +27,21 @@ const (
 type ContentType string
 
 const (
-	Raw  ContentType = "raw-text"
-	Node ContentType = "node"
+	Raw              ContentType = "raw-text"
+	Escaped          ContentType = "escaped-text"
+	Node             ContentType = "node"
+	FragmentContent  ContentType = "fragment"
+	Empty            ContentType = "nothing"
+	ComponentContent ContentType = "component"
+	LayoutContent    ContentType = "layout"
+	SlotContent      ContentType = "slot"
+	ProviderContent  ContentType = "provider"
+	ConsumerContent  ContentType = "consumer"
+	FlushPoint       ContentType = "flush"
+	AsyncContent     ContentType = "async"
+	LazyContent      ContentType = "lazy"
+	CompiledContent  ContentType = "compiled"
+	CachedContent    ContentType = "cached"
 )
 
 type HTMLRawContent struct {
@@ -42,6 +59,17 @@ type HTMLContent struct {
 	ctType ContentType
 	child  HTMLElement
 	raw    HTMLRawContent
+	// fragment contents
+	children  []HTMLContent
+	component Component
+	layout    *layoutNode
+	slot      *slotNode
+	provider  *providerNode
+	consumer  func(ctx context.Context) HTMLContent
+	async     *asyncNode
+	lazy      *lazyNode
+	compiled  *compiledNode
+	cached    *cachedNode
 }
 
 // ref: https://github.com/golang/go/issues/62005#issuecomment-1747630201
@@ -53,8 +81,13 @@ func NopLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(new(NopWritter), nil))
 }
 
+// default logger of every build
+var nopLogger = NopLogger()
+
 type buildConfig struct {
+	ctx       context.Context
 	stdWriter io.Writer
+	out       *renderWriter
 	debug     struct {
 		logger *slog.Logger
 	}
@@ -62,6 +95,24 @@ type buildConfig struct {
 		isEnable         bool
 		indentationLevel uint8
 	}
+	pretty    prettyState
+	minify    bool
+	siblings  siblingScope
+	urlPolicy URLPolicy
+	strict    bool
+	validate  bool
+	// emitted ids and the path of the element holding them
+	ids   map[string]string
+	path  elementPath
+	slots []slotScope
+	// tags flushed right after being written
+	flushAfter map[string]struct{}
+	async      asyncState
+	lazy       *lazyPool
+	result     *RenderResult
+	cache      Cache
+	// set when the build is compiling a content
+	compiling *compiler
 }
 
 type buildOpt func(config *buildConfig)
@@ -83,143 +134,487 @@ func WithDefaultIndentation() buildOpt {
 	return func(config *buildConfig) {
 		config.indentitation.indentationLevel = 4
 		config.indentitation.isEnable = true
+		config.minify = false
 	}
 }
 
 // Choose a indentation level between 0 and 255.
+//
+// The contents of a block element are written one per line when any of
+// them is a block element, i.g.:
+//
+//	<div>
+//	    <p>some <b>bold</b> text</p>
+//	    <hr/>
+//	</div>
+//
+// Inline contents (texts and inline elements) next to each other are kept
+// on the same line, without any whitespace between them, since it would
+// change how they're rendered. The whitespace surrounding texts is dropped
+// at the start and end of the lines, so printing a tree holding the
+// whitespace of a printed document gives the same output. The contents of
+// pre, textarea, script, style and title are written as they are.
 func WithIndentation(level int) buildOpt {
 	return func(config *buildConfig) {
 		config.indentitation.indentationLevel = uint8(level)
 		config.indentitation.isEnable = true
+		config.minify = false
 	}
 }
 
-func (ele HTMLElement) BuildDOM(opts ...buildOpt) error {
-	return buildDOM(ele, opts...)
+// Choose which URLs are allowed on URL attributes (src, href, action...).
+// Unsafe URLs are replaced by UnsafeURLPlaceholder and a nil policy
+// disables the check.
+func WithURLPolicy(policy URLPolicy) buildOpt {
+	return func(config *buildConfig) {
+		config.urlPolicy = policy
+	}
 }
 
-func (ct HTMLContent) BuildDOM(opts ...buildOpt) error {
-	return buildDOM(ct.child, opts...)
+// Strict mode turns misuses of the DSL into build errors instead of
+// silently fixing them, i.g.: a Single attribute built with values,
+// a void element with children or an empty tag name.
+// Errors are returned as *BuildError holding the path of the element.
+func WithStrictMode() buildOpt {
+	return func(config *buildConfig) {
+		config.strict = true
+	}
 }
 
-func buildDOM(ele HTMLElement, opts ...buildOpt) error {
-	var totalWritten int
-	defaultCfg := new(buildConfig)
-	defaultCfg.debug.logger = NopLogger()
+// Flush the writer right after writing any of the given elements,
+// i.g.: WithFlushAfter("head") lets the browser fetch the scripts while
+// the body is still being built. The buffered output is always written
+// to the writer, which is flushed as well when it's an http.Flusher.
+func WithFlushAfter(tagNames ...string) buildOpt {
+	return func(config *buildConfig) {
+		if config.flushAfter == nil {
+			config.flushAfter = make(map[string]struct{}, len(tagNames))
+		}
+		for _, t := range tagNames {
+			config.flushAfter[t] = struct{}{}
+		}
+	}
+}
+
+// Track every emitted id, logging a warning for the duplicated ones.
+// On strict mode a duplicated id fails the build with a *DuplicateIDError.
+func WithUniqueIDs() buildOpt {
+	return func(config *buildConfig) {
+		config.ids = make(map[string]string)
+	}
+}
+
+func (ele HTMLElement) BuildDOM(opts ...buildOpt) error {
+	_, err := Render(context.Background(), HTMLContent{ctType: Node, child: ele}, opts...)
+	return err
+}
+
+func (ct HTMLContent) BuildDOM(opts ...buildOpt) error {
+	_, err := Render(context.Background(), ct, opts...)
+	return err
+}
+
+// BuildDOMContext builds the DOM with a context, which can be read by
+// Consume contents while building. The context is checked before each
+// element, so a canceled context stops the build with a *BuildError
+// wrapping ctx.Err().
+func (ele HTMLElement) BuildDOMContext(ctx context.Context, opts ...buildOpt) error {
+	_, err := Render(ctx, HTMLContent{ctType: Node, child: ele}, opts...)
+	return err
+}
+
+// BuildDOMContext builds the DOM with a context, which can be read by
+// Consume contents while building. The context is checked before each
+// element, so a canceled context stops the build with a *BuildError
+// wrapping ctx.Err().
+func (ct HTMLContent) BuildDOMContext(ctx context.Context, opts ...buildOpt) error {
+	_, err := Render(ctx, ct, opts...)
+	return err
+}
+
+// Render works like BuildDOMContext, also returning the build metrics.
+// The result is filled even when the build fails, with what was done
+// until the failure.
+func Render(ctx context.Context, root HTMLContent, opts ...buildOpt) (result RenderResult, err error) {
+	start := time.Now()
+	cfg := &buildConfig{result: &result}
+	defer func() {
+		result.Duration = time.Since(start)
+	}()
+
+	err = cfg.build(ctx, root, opts...)
+	return result, err
+}
+
+func (cfg *buildConfig) build(ctx context.Context, root HTMLContent, opts ...buildOpt) (err error) {
+	cfg.ctx = ctx
+	cfg.debug.logger = nopLogger
+	cfg.urlPolicy = DefaultURLPolicy
 	for _, op := range opts {
-		op(defaultCfg)
+		op(cfg)
 	}
 
-	if defaultCfg.stdWriter == nil {
+	if cfg.stdWriter == nil {
 		return ErrWriterNotFound
 	}
 
-	// hardcoded html document compliance
-	if ele.tagName == "html" {
-		totalWritten, _ = defaultCfg.stdWriter.Write([]byte("<!DOCTYPE html>\n"))
+	if cfg.validate {
+		if err := ValidateContext(ctx, root); err != nil {
+			return err
+		}
+	}
+
+	cfg.pretty = prettyState{block: true}
+
+	// everything is buffered until a flush point or the end of the build
+	cfg.out = newRenderWriter(cfg.stdWriter)
+	defer func() {
+		if flushErr := cfg.out.release(); err == nil {
+			err = flushErr
+		}
+	}()
+
+	// async contents still running when the build is done are useless
+	defer cfg.async.cancelAll()
+
+	if cfg.lazy != nil {
+		cfg.lazy.start(ctx)
+		defer cfg.lazy.stop()
+		cfg.lazy.prefetch(ctx, root, nil, nil)
+	}
+
+	n, err := cfg.parseContent(root, -1, 1)
+	cfg.result.BytesWritten += n
+	if err != nil {
+		return err
 	}
 
-	n, err := defaultCfg.parseElement(ele, 1)
+	n, err = cfg.writeAsyncs()
+	cfg.result.BytesWritten += n
 	if err != nil {
 		return err
 	}
-	totalWritten += n
 
-	defaultCfg.debug.logger.Debug("Element was written with: ",
-		"tag_name", ele.tagName, "bytes", totalWritten)
+	cfg.debug.logger.Debug("Element was written with: ",
+		"tag_name", root.child.tagName, "content_type", root.ctType, "bytes", cfg.result.BytesWritten)
 	return nil
 }
 
-func (cfg *buildConfig) parseElement(ele HTMLElement, tagDepth int) (int, error) {
-	var attrStr string
-	var rIndentStr, lIndentStr string
-	var attrKeys []string
-	var totalWritten int
+// parseContent writes the content as an element node, a text or the
+// contents of a fragment.
+func (cfg *buildConfig) parseContent(ct HTMLContent, index int, tagDepth int) (int, error) {
+	if cfg.compiling != nil && isDynamic(ct.ctType) {
+		return 0, cfg.addHole(ct, index, tagDepth)
+	}
 
-	writeOrErr := func(s string) error {
-		n, err := cfg.stdWriter.Write([]byte(s))
-		totalWritten += n
+	// only the direct contents of an element are known siblings
+	if cfg.minify && (ct.ctType == FragmentContent || isDynamic(ct.ctType)) {
+		outerSiblings := cfg.siblings
+		cfg.siblings = siblingScope{}
+		defer func() { cfg.siblings = outerSiblings }()
+	}
+
+	switch ct.ctType {
+	case Node:
+		return cfg.parseElement(ct.child, index, tagDepth)
+	case Raw:
+		return cfg.writeText(ct.raw.text, false, tagDepth)
+	case Escaped:
+		return cfg.writeText(ct.raw.text, true, tagDepth)
+	case Empty:
+		return 0, nil
+	case FlushPoint:
+		return 0, cfg.flush()
+	case AsyncContent:
+		cfg.path.push(asyncPathName, index)
+		defer cfg.path.pop()
+
+		if ct.async.fn == nil {
+			return 0, cfg.buildErr(ErrNilFunc)
+		}
+		return cfg.parseAsync(ct.async, tagDepth)
+	case LazyContent:
+		cfg.path.push(lazyPathName, index)
+		defer cfg.path.pop()
+
+		if ct.lazy.fn == nil {
+			return 0, cfg.buildErr(ErrNilFunc)
+		}
+		content, err := cfg.resolveLazy(ct.lazy)
+		if err != nil {
+			return 0, err
+		}
+		return cfg.parseContent(content, -1, tagDepth)
+	case CompiledContent:
+		cfg.path.push(compiledPathName, index)
+		defer cfg.path.pop()
+
+		return cfg.parseCompiled(ct.compiled, tagDepth)
+	case CachedContent:
+		cfg.path.push(cachedPathName(ct.cached), index)
+		defer cfg.path.pop()
+
+		if ct.cached.fn == nil {
+			return 0, cfg.buildErr(ErrNilFunc)
+		}
+		return cfg.parseCached(ct.cached, tagDepth)
+	case ComponentContent:
+		if ct.component == nil {
+			return 0, cfg.buildErr(ErrNilComponent)
+		}
+		name := componentPathName(ct.component)
+		cfg.path.push(name, index)
+		defer cfg.path.pop()
+
+		if !canRender(ct.component) {
+			return 0, cfg.buildErr(ErrNilFunc)
+		}
+		start := time.Now()
+		n, err := cfg.parseContent(ct.component.Render(), -1, tagDepth)
+		cfg.result.trackComponent(componentName(ct.component), time.Since(start))
+		// the path is only built when it's going to be logged
+		if cfg.debug.logger.Enabled(cfg.ctx, slog.LevelDebug) {
+			cfg.debug.logger.Debug("Component was written with: ",
+				"component", name, "path", cfg.path.String(), "bytes", n)
+		}
+		return n, err
+	case LayoutContent:
+		cfg.path.push(layoutPathName(ct.layout), index)
+		defer cfg.path.pop()
+
+		outerSlots := cfg.slots
+		cfg.slots = append(cfg.slots, ct.layout.scope())
+		defer func() { cfg.slots = outerSlots }()
+
+		start := time.Now()
+		n, err := cfg.parseContent(ct.layout.body, -1, tagDepth)
+		cfg.result.trackComponent(ct.layout.name, time.Since(start))
+		return n, err
+	case SlotContent:
+		cfg.path.push(slotPathName(ct.slot), index)
+		defer cfg.path.pop()
+
+		outerSlots := cfg.slots
+		var contents []HTMLContent
+		contents, cfg.slots = resolveSlot(cfg.slots, ct.slot)
+		defer func() { cfg.slots = outerSlots }()
+
+		return cfg.parseContents(contents, tagDepth)
+	case ProviderContent:
+		cfg.path.push(providerPathName, index)
+		defer cfg.path.pop()
+
+		outerCtx := cfg.ctx
+		cfg.ctx = ct.provider.provide(cfg.ctx)
+		defer func() { cfg.ctx = outerCtx }()
+
+		return cfg.parseContents(ct.provider.contents, tagDepth)
+	case ConsumerContent:
+		cfg.path.push(consumerPathName, index)
+		defer cfg.path.pop()
+
+		if ct.consumer == nil {
+			return 0, cfg.buildErr(ErrNilFunc)
+		}
+		return cfg.parseContent(ct.consumer(cfg.ctx), -1, tagDepth)
+	case FragmentContent:
+		cfg.path.push(fragmentPathName, index)
+		defer cfg.path.pop()
+
+		return cfg.parseContents(ct.children, tagDepth)
+	default:
+		return 0, cfg.buildErr(fmt.Errorf("%w: [%s]", ErrUnknownContentType, ct.ctType))
+	}
+}
+
+// flush sends the buffered output to the writer, also flushing the writer
+// itself when it's an http.Flusher.
+func (cfg *buildConfig) flush() error {
+	// the flush happens on each build of the compiled content
+	if cfg.compiling != nil {
+		return cfg.addHole(Flush(), -1, 0)
+	}
+	if err := cfg.out.flush(); err != nil {
 		return err
 	}
+	if f, ok := cfg.stdWriter.(http.Flusher); ok {
+		f.Flush()
+		cfg.debug.logger.Debug("Writer was flushed at: ", "path", cfg.path.String())
+	}
+	return nil
+}
+
+func (cfg *buildConfig) flushIfAfter(ele HTMLElement) error {
+	if _, ok := cfg.flushAfter[ele.tagName]; ok {
+		return cfg.flush()
+	}
+	return nil
+}
 
-	putNChar := func(s string, ch string, n int) string {
-		for i := 0; i < n; i++ {
-			s += ch
+func (cfg *buildConfig) parseContents(contents []HTMLContent, tagDepth int) (int, error) {
+	var totalWritten int
+	for i, ct := range contents {
+		n, err := cfg.parseContent(ct, i, tagDepth)
+		totalWritten += n
+		if err != nil {
+			return totalWritten, err
+		}
+	}
+	return totalWritten, nil
+}
+
+func (cfg *buildConfig) parseElement(ele HTMLElement, index int, tagDepth int) (int, error) {
+	out := cfg.out
+	start := out.written
+
+	// hardcoded html document compliance, roots can also be wrapped by
+	// layouts or components
+	if ele.tagName == "html" && !cfg.path.hasElements() {
+		if _, err := out.WriteString("<!DOCTYPE html>"); err != nil {
+			return out.since(start), err
 		}
-		return s
 	}
 
-	// TODO: fix indentatin bug!
-	if cfg.indentitation.isEnable {
-		rIndentStr = putNChar("\n", " ", tagDepth*int(cfg.indentitation.indentationLevel))
-		lIndentStr = putNChar("\n", " ", (tagDepth-1)*int(cfg.indentitation.indentationLevel))
+	cfg.path.push(ele.tagName, index)
+	defer cfg.path.pop()
+
+	// stops big builds as soon as the client is gone
+	if err := cfg.ctx.Err(); err != nil {
+		return out.since(start), cfg.buildErr(err)
 	}
 
-	// rules:
-	// 1. we need to merge all attributes with the same name
-	// 2. if element has no attrs, the space become a suffix and will be removed
-	attrMap := make(map[string]HTMLAttribute)
-	for _, attr := range ele.attrs {
-		if curAttr, ok := attrMap[attr.name]; ok {
-			curAttr.values = append(curAttr.values, attr.values...)
-			attrMap[attr.name] = curAttr
-		} else {
-			attrKeys = append(attrKeys, attr.name)
-			attrMap[attr.name] = attr
+	cfg.result.Elements++
+	cfg.result.MaxDepth = max(cfg.result.MaxDepth, tagDepth)
+
+	if cfg.strict {
+		switch {
+		case !isValidTagName(ele.tagName):
+			return out.since(start), cfg.buildErr(fmt.Errorf("%w: [%s]", ErrInvalidTagName, ele.tagName))
+		case ele.elType != Void && ele.elType != NonVoid:
+			return out.since(start), cfg.buildErr(fmt.Errorf("%w: [%s]", ErrUnknownElementType, ele.elType))
+		case ele.elType == Void && hasContents(ele.contents):
+			return out.since(start), cfg.buildErr(fmt.Errorf("%w: <%s> has %d", ErrVoidWithChildren, ele.tagName, len(ele.contents)))
 		}
 	}
 
-	// TODO: find another aproach to have all the parsed attributes in O(n)
-	for _, k := range attrKeys {
-		attrStr += " " + attrMap[k].String()
+	// attributes are checked before writing anything, so a failed element
+	// is never half written
+	if err := cfg.checkAttrs(ele.attrs); err != nil {
+		return out.since(start), err
+	}
+
+	inline := isInlineElement(ele.tagName)
+	if err := cfg.startContent(inline, tagDepth); err != nil {
+		return out.since(start), err
+	}
+
+	// svg and math elements can be self-closed, so the minifier keeps
+	// their slashes and quotes
+	foreign := isForeignElement(ele.tagName, cfg.pretty.foreign)
+	minify := cfg.minify && !foreign
+
+	out.WriteString("<")
+	out.WriteString(ele.tagName)
+	for i, attr := range ele.attrs {
+		// rules:
+		// 1. we need to merge all attributes with the same name
+		// 2. only the first one is written, holding the values of the others
+		if !isRenderedAttr(attr.attrType) || isMergedAttr(ele.attrs, i) {
+			continue
+		}
+		out.WriteString(" ")
+		write := writeAttr
+		if minify {
+			write = writeMinAttr
+		}
+		if err := write(out, ele.attrs, i, cfg.urlPolicy); err != nil {
+			return out.since(start), err
+		}
 	}
-	attrStr = strings.TrimSuffix(attrStr, " ")
 
 	switch ele.elType {
 	// void -> <[tag][?attrs]/>
 	case Void:
-		if err := writeOrErr(fmt.Sprintf(`<%s%s/>`, ele.tagName, attrStr)); err != nil {
-			return totalWritten, err
+		// an unquoted value would take the slash
+		closing := "/>"
+		if minify {
+			closing = ">"
+		}
+		if _, err := out.WriteString(closing); err != nil {
+			return out.since(start), err
 		}
-		return totalWritten, nil
+		return out.since(start), cfg.flushIfAfter(ele)
 
 	// non-void -> <[tag][?attrs]>[content]</[tag]>
 	default:
-		if err := writeOrErr("<" + ele.tagName + attrStr + ">"); err != nil {
-			return totalWritten, err
+		if _, err := out.WriteString(">"); err != nil {
+			return out.since(start), err
 		}
 
-		if len(ele.contents) > 0 {
-			_ = writeOrErr(rIndentStr)
+		// inline elements can't get whitespace, neither the ones holding
+		// preformatted text
+		outer := cfg.pretty
+		verbatim := outer.verbatim || isVerbatimElement(ele.tagName)
+		block := !inline && !verbatim &&
+			(cfg.minify || cfg.indentitation.isEnable && isMultiline(ele.contents))
+		cfg.pretty = prettyState{
+			block:    block,
+			verbatim: verbatim,
+			foreign:  foreign && hasForeignChildren(ele.tagName),
 		}
 
-		// threat as element node or just an raw text
-		for _, ct := range ele.contents {
-			switch ct.ctType {
-			case Node:
-				n, err := cfg.parseElement(ct.child, tagDepth+1)
-				if err != nil {
-					return totalWritten, err
-				}
-				totalWritten += n
-			case Raw:
-				if err := writeOrErr(ct.raw.text); err != nil {
-					return totalWritten, err
-				}
-			default:
-				return totalWritten, fmt.Errorf("not recognized content type: [%s]", ct.ctType)
+		outerSiblings := cfg.siblings
+		if cfg.minify {
+			cfg.siblings = siblingScope{parent: ele.tagName, contents: ele.contents}
+		}
+
+		// threat as element node, raw text, fragment...
+		if _, err := cfg.parseContents(ele.contents, tagDepth+1); err != nil {
+			return out.since(start), err
+		}
+
+		if block {
+			cfg.breakLine(tagDepth)
+		}
+		cfg.pretty, cfg.siblings = outer, outerSiblings
+
+		if !minify || !cfg.canOmitEndTag(ele, index) {
+			out.WriteString("</")
+			out.WriteString(ele.tagName)
+			if _, err := out.WriteString(">"); err != nil {
+				return out.since(start), err
 			}
 		}
+		return out.since(start), cfg.flushIfAfter(ele)
+	}
+}
 
-		if len(ele.contents) > 0 {
-			_ = writeOrErr(lIndentStr)
+// checkAttrs validates the attribute names and tracks the element id.
+func (cfg *buildConfig) checkAttrs(attrs []HTMLAttribute) error {
+	for i, attr := range attrs {
+		if attr.attrType == None {
+			continue
+		}
+		if !isValidAttrName(attr.name) {
+			return cfg.buildErr(fmt.Errorf("%w: [%s]", ErrInvalidAttrName, attr.name))
+		}
+		if isMergedAttr(attrs, i) {
+			continue
 		}
 
-		if err := writeOrErr("</" + ele.tagName + ">"); err != nil {
-			return totalWritten, err
+		if attr.name == "id" && cfg.ids != nil {
+			if err := cfg.trackID(strings.Join(mergedAttrValues(attrs, i), " ")); err != nil {
+				return err
+			}
+		}
+
+		// a Single attribute can't hold values, so either it's a mistake
+		// or the values must be rendered
+		if cfg.strict && attr.attrType == Single && hasMergedValues(attrs, i) {
+			return cfg.buildErr(fmt.Errorf("%w: [%s]", ErrSingleWithValue, attr.name))
 		}
-		return totalWritten, nil
 	}
+	return nil
 }
 
 /*
@@ -233,6 +628,8 @@ const (
 	None         AttributeType = "none"
 	Single       AttributeType = "single"
 	DoubleQuoted AttributeType = "double-quoted"
+	// double-quoted attribute checked against the build URL policy
+	URL AttributeType = "url"
 )
 
 type HTMLAttribute struct {
@@ -242,89 +639,157 @@ type HTMLAttribute struct {
 	attrType AttributeType
 }
 
+// String renders the attribute with its values escaped. Attributes with
+// an invalid name are rendered as an empty string.
 func (attr HTMLAttribute) String() string {
-	switch attr.attrType {
-	case DoubleQuoted:
-		var st string
-		for _, v := range attr.values {
-			st += v + " "
-		}
-		return fmt.Sprintf(`%s="%s"`, attr.name, strings.TrimSuffix(st, " "))
-	case Single:
-		return attr.name
-	case None:
-		return ""
-	default:
+	if attr.attrType != None && !isValidAttrName(attr.name) {
 		return ""
 	}
+	var sb strings.Builder
+	_ = writeAttr(&sb, []HTMLAttribute{attr}, 0, DefaultURLPolicy)
+	return sb.String()
 }
 
-/* Attributes functions declarations */
-func Attr(name string, attrType AttributeType, values ...string) HTMLAttribute {
-	return HTMLAttribute{name: name, values: values, attrType: attrType}
+func isRenderedAttr(attrType AttributeType) bool {
+	return attrType == Single || attrType == DoubleQuoted || attrType == URL
 }
 
-func ClassNames(values ...string) HTMLAttribute {
-	return Attr("class", DoubleQuoted, values...)
+// isMergedAttr reports if the i-th attribute is merged into a previous one
+// with the same name.
+func isMergedAttr(attrs []HTMLAttribute, i int) bool {
+	for _, prev := range attrs[:i] {
+		if prev.attrType != None && prev.name == attrs[i].name {
+			return true
+		}
+	}
+	return false
 }
 
-func PlaceHolder(value string) HTMLAttribute {
-	return Attr("placeholder", DoubleQuoted, value)
+// hasMergedValues reports if the i-th attribute, or any of the next ones
+// with the same name, holds values.
+func hasMergedValues(attrs []HTMLAttribute, i int) bool {
+	for _, next := range attrs[i:] {
+		if next.attrType != None && next.name == attrs[i].name && len(next.values) > 0 {
+			return true
+		}
+	}
+	return false
 }
 
-func Id(values ...string) HTMLAttribute {
-	return Attr("id", DoubleQuoted, values...)
+func mergedAttrValues(attrs []HTMLAttribute, i int) (values []string) {
+	for _, next := range attrs[i:] {
+		if next.attrType != None && next.name == attrs[i].name {
+			values = append(values, next.values...)
+		}
+	}
+	return
 }
 
-func Name(values ...string) HTMLAttribute {
-	return Attr("name", DoubleQuoted, values...)
-}
+// writeAttr writes the i-th attribute along with the values of the next
+// attributes with the same name, which keeps the type of the first one.
+func writeAttr(w io.StringWriter, attrs []HTMLAttribute, i int, urlPolicy URLPolicy) error {
+	attr := attrs[i]
+	if !isRenderedAttr(attr.attrType) {
+		return nil
+	}
 
-func Lang(values ...string) HTMLAttribute {
-	return Attr("lang", DoubleQuoted, values...)
-}
+	attrType := attr.attrType
+	if attrType == Single && hasMergedValues(attrs, i) {
+		attrType = DoubleQuoted
+	}
 
-func Type(values ...string) HTMLAttribute {
-	return Attr("type", DoubleQuoted, values...)
-}
+	if _, err := w.WriteString(attr.name); err != nil || attrType == Single {
+		return err
+	}
+	if _, err := w.WriteString(`="`); err != nil {
+		return err
+	}
 
-func Value(values ...string) HTMLAttribute {
-	return Attr("value", DoubleQuoted, values...)
-}
+	first := true
+	for _, next := range attrs[i:] {
+		if next.attrType == None || next.name != attr.name {
+			continue
+		}
+		for _, v := range next.values {
+			if !first {
+				if _, err := w.WriteString(" "); err != nil {
+					return err
+				}
+			}
+			first = false
 
-func Src(values ...string) HTMLAttribute {
-	return Attr("src", DoubleQuoted, values...)
+			if attrType == URL {
+				v = sanitizeURL(urlPolicy, v)
+			}
+			if err := writeEscaped(w, v, &attrEscapes); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := w.WriteString(`"`)
+	return err
 }
 
-func Defer() HTMLAttribute {
-	return Attr("defer", Single)
+// Ref: https://html.spec.whatwg.org/multipage/syntax.html#attributes-2
+// Also rejects '<' and '`' since they're only useful to break out of a tag.
+func isValidAttrName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r <= 0x1f, r >= 0x7f && r <= 0x9f:
+			// control characters
+			return false
+		case r == ' ', r == '"', r == '\'', r == '>', r == '/', r == '=', r == '<', r == '`':
+			return false
+		case r >= 0xfdd0 && r <= 0xfdef, r&0xfffe == 0xfffe:
+			// noncharacters
+			return false
+		case r == utf8.RuneError:
+			return false
+		}
+	}
+	return true
 }
 
-func Checked() HTMLAttribute {
-	return Attr("checked", Single)
+/* Attributes functions declarations */
+func Attr(name string, attrType AttributeType, values ...string) HTMLAttribute {
+	return HTMLAttribute{name: name, values: values, attrType: attrType}
 }
 
-func Required() HTMLAttribute {
-	return Attr("required", Single)
+// All the standard attribute helpers (Id, Href, Disabled...) are generated
+// from gen/attributes.txt into attributes_gen.go.
+
+// Dataset builds a custom data-* attribute, i.g.: Dataset("id", "1").
+func Dataset(name string, values ...string) HTMLAttribute {
+	return Attr("data-"+name, DoubleQuoted, values...)
 }
 
-func Action(values ...string) HTMLAttribute {
-	return Attr("action", Single, values...)
+// On builds an inline event handler attribute, i.g.: On("click", "...").
+func On(event, script string) HTMLAttribute {
+	return Attr("on"+event, DoubleQuoted, script)
 }
 
-func Method(values ...string) HTMLAttribute {
-	return Attr("method", Single, values...)
+// NothingAttr renders zero bytes, it's the attribute to return when
+// there's nothing to render, i.g.: on conditionals.
+func NothingAttr() HTMLAttribute {
+	return HTMLAttribute{attrType: None}
 }
 
 /* Attributes utils */
 func IsChecked(check bool) (attr HTMLAttribute) {
-	if check {
-		return Checked()
-	}
-	return HTMLAttribute{attrType: None}
+	return IfAttr(check, Checked())
 }
 
 /* Tags functions declarations */
+
+// All the HTML element helpers (Div, Input, Table...) are generated from
+// gen/elements.txt into elements_gen.go.
+//
+//go:generate go run ./gen
+
 type tagClosure func(contents ...HTMLContent) HTMLContent
 
 func Tag(tagName string, elType ElementType, attrs ...HTMLAttribute) tagClosure {
@@ -341,62 +806,51 @@ func Tag(tagName string, elType ElementType, attrs ...HTMLAttribute) tagClosure
 	}
 }
 
-func RawText(text string) HTMLContent {
-	return HTMLContent{raw: HTMLRawContent{text: text}, ctType: Raw}
-}
-
-func Input(attrs ...HTMLAttribute) HTMLContent {
-	return Tag("input", Void, attrs...)()
-}
-
-func Button(attrs ...HTMLAttribute) tagClosure {
-	return Tag("button", NonVoid, attrs...)
-}
-
-func Script(attrs ...HTMLAttribute) tagClosure {
-	return Tag("script", NonVoid, attrs...)
-}
-
-func Title(attrs ...HTMLAttribute) tagClosure {
-	return Tag("title", NonVoid, attrs...)
-}
-
-func Head(attrs ...HTMLAttribute) tagClosure {
-	return Tag("head", NonVoid, attrs...)
-}
-
-func Div(attrs ...HTMLAttribute) tagClosure {
-	return Tag("div", NonVoid, attrs...)
-}
-
-func Body(attrs ...HTMLAttribute) tagClosure {
-	return Tag("body", NonVoid, attrs...)
-}
-
-func Html(attrs ...HTMLAttribute) tagClosure {
-	return Tag("html", NonVoid, attrs...)
-}
-
-func Form(attrs ...HTMLAttribute) tagClosure {
-	return Tag("form", NonVoid, attrs...)
+// Text is the default way to put text inside an element: it's HTML-escaped
+// on render, so it's safe to use with user supplied values.
+func Text(text string) HTMLContent {
+	return HTMLContent{raw: HTMLRawContent{text: text}, ctType: Escaped}
 }
 
-func Label(attrs ...HTMLAttribute) tagClosure {
-	return Tag("label", NonVoid, attrs...)
+// RawText writes the text verbatim, without any escaping.
+// Only use it for trusted markup, never for user supplied values.
+func RawText(text string) HTMLContent {
+	return HTMLContent{raw: HTMLRawContent{text: text}, ctType: Raw}
 }
 
-func Table(attrs ...HTMLAttribute) tagClosure {
-	return Tag("table", NonVoid, attrs...)
+// Nothing renders zero bytes, it's the content to return when there's
+// nothing to render, i.g.: on conditionals.
+func Nothing() HTMLContent {
+	return HTMLContent{ctType: Empty}
 }
 
-func Th(attrs ...HTMLAttribute) tagClosure {
-	return Tag("th", NonVoid, attrs...)
+// hasContents reports if any of the contents renders something, so empty
+// elements don't get indentation.
+func hasContents(contents []HTMLContent) bool {
+	for _, ct := range contents {
+		switch ct.ctType {
+		case Empty, FlushPoint:
+		case FragmentContent:
+			if hasContents(ct.children) {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
 }
 
-func Tr(attrs ...HTMLAttribute) tagClosure {
-	return Tag("tr", NonVoid, attrs...)
+// Flush marks where the buffered output must be written to the writer,
+// which is flushed as well when it's an http.Flusher, sending everything
+// written so far to the client.
+func Flush() HTMLContent {
+	return HTMLContent{ctType: FlushPoint}
 }
 
-func Td(attrs ...HTMLAttribute) tagClosure {
-	return Tag("td", NonVoid, attrs...)
+// Fragment groups contents without a wrapper tag, so it can be used to
+// build many root elements, i.g.: two table rows or an htmx OOB swap
+// along with the main content.
+func Fragment(contents ...HTMLContent) HTMLContent {
+	return HTMLContent{children: contents, ctType: FragmentContent}
 }
@@ -0,0 +1,103 @@
+package go_ml
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"testing"
+)
+
+// a table with the common cases: escaped text, merged attributes, URL
+// attributes and void elements
+func benchTable(rows int) HTMLContent {
+	trs := make([]HTMLContent, rows)
+	for i := range trs {
+		id := strconv.Itoa(i)
+		trs[i] = Tr(Id("row-"+id), ClassNames("row"), ClassNames("striped"))(
+			Td()(Text("todo <"+id+"> & more")),
+			Td()(A(Href("/todo/"+id), TitleAttr("edit \"todo\""))(Text("edit"))),
+			Td()(Input(Type("checkbox"), Checked(), Disabled())),
+		)
+	}
+	return Html(Lang("en"))(
+		Head()(Title()(Text("bench"))),
+		Body()(Table()(Tbody()(trs...))),
+	)
+}
+
+func BenchmarkBuildDOM(b *testing.B) {
+	for _, rows := range []int{10, 100, 1000} {
+		dom := benchTable(rows)
+		b.Run("rows-"+strconv.Itoa(rows), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := dom.BuildDOM(WithWriter(io.Discard)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBuildDOMIndented(b *testing.B) {
+	dom := benchTable(100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := dom.BuildDOM(WithWriter(io.Discard), WithDefaultIndentation()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBuildDOMMinified(b *testing.B) {
+	dom := benchTable(100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := dom.BuildDOM(WithWriter(io.Discard), WithMinify()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBuildDOMComponents(b *testing.B) {
+	items := make([]testItem, 100)
+	for i := range items {
+		items[i] = testItem{text: "item " + strconv.Itoa(i)}
+	}
+	dom := Ul()(Map(items, func(item testItem) HTMLContent { return Use(item) }))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := dom.BuildDOM(WithWriter(io.Discard)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestBuildDOMAllocs(t *testing.T) {
+	small, big := benchTable(10), benchTable(1000)
+	allocs := func(dom HTMLContent) float64 {
+		return testing.AllocsPerRun(100, func() {
+			if _, err := Render(context.Background(), dom, WithWriter(io.Discard)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	// the allocations are per build, never per element: the race detector
+	// randomly drops pooled buffers, hence the small margin
+	smallAllocs, bigAllocs := allocs(small), allocs(big)
+	if bigAllocs > smallAllocs+2 {
+		t.Errorf("allocations grow with the elements: 10 rows: %v, 1000 rows: %v", smallAllocs, bigAllocs)
+	}
+}
+
+func BenchmarkBuildDOMCompiled(b *testing.B) {
+	dom := MustCompile(benchTable(100))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := dom.BuildDOM(WithWriter(io.Discard)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
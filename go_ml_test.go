@@ -1,10 +1,42 @@
 package go_ml
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+)
+
+type testItem struct {
+	text string
+}
+
+func (item testItem) Render() HTMLContent {
+	return Li()(Text(item.text))
+}
+
+var testCard = NewComponent("Card", func(title string, children ...HTMLContent) HTMLContent {
+	return Div(ClassNames("card"))(H2()(Text(title)), Fragment(children...))
+})
+
+var (
+	testSiteLayout = NewLayout("Site", Div(Id("site"))(
+		Header()(Yield("head", Text("default head"))),
+		Main()(Yield("main")),
+		Footer()(Yield("footer", Text("default footer"))),
+	))
+	testSectionLayout = testSiteLayout.Extend("Section",
+		Fill("main", Aside()(Text("menu")), Section()(Yield("content"))),
+		Fill("footer", Text("section footer")),
+	)
 )
 
 func TestBasicDOM(t *testing.T) {
@@ -13,6 +45,7 @@ func TestBasicDOM(t *testing.T) {
 		givenDOM     HTMLContent
 		buildOpts    []buildOpt
 		expectedHtml string
+		expectedErr  error
 	}{
 		{
 			name:         "build empty html document",
@@ -59,6 +92,21 @@ func TestBasicDOM(t *testing.T) {
 			expectedHtml: `<div>olá &#10; mundo<div></div>test<div></div></div>`,
 			givenDOM:     Div()(RawText("olá &#10; mundo"), Div()(), RawText("test"), Div()()),
 		},
+		{
+			name:         "build non-void tag with escaped inner text",
+			expectedHtml: `<div>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; &amp; mundo</div>`,
+			givenDOM:     Div()(Text(`<script>alert("x")</script> & mundo`)),
+		},
+		{
+			name:         "build non-void tag with escaped and raw inner text",
+			expectedHtml: `<div>a &lt; b<br/>a < b</div>`,
+			givenDOM:     Div()(Text("a < b"), RawText("<br/>a < b")),
+		},
+		{
+			name:         "build generated void and non-void elements",
+			expectedHtml: `<p><img src="a.png"/><br/><span>text</span></p>`,
+			givenDOM:     P()(Img(Src("a.png")), Br(), Span()(Text("text"))),
+		},
 		{
 			name:         "build input with mutiple class attributes",
 			expectedHtml: `<input name="task" class="text name editable"/>`,
@@ -83,7 +131,7 @@ func TestBasicDOM(t *testing.T) {
         </div>
     </div>
 </div>`,
-			givenDOM:  Div(ClassNames("main", "container"))(Div()(
+			givenDOM: Div(ClassNames("main", "container"))(Div()(
 				Div()(
 					Div()(
 						Div()(),
@@ -92,14 +140,290 @@ func TestBasicDOM(t *testing.T) {
 			)),
 			buildOpts: []buildOpt{WithDefaultIndentation()},
 		},
+		{
+			name:         "build input with escaped attribute values",
+			expectedHtml: `<input value="&#34;><script>alert(1)</script> &amp; '"/>`,
+			givenDOM:     Input(Value(`"><script>alert(1)</script>`, "&", "'")),
+		},
+		{
+			name:        "build input with injected attribute name",
+			givenDOM:    Input(Attr(`x="y"><script>alert(1)</script`, Single)),
+			expectedErr: ErrInvalidAttrName,
+		},
+		{
+			name:        "build input with spaced attribute name",
+			givenDOM:    Input(Attr("on click", DoubleQuoted, "x")),
+			expectedErr: ErrInvalidAttrName,
+		},
+		{
+			name:         "build form with relative action url",
+			expectedHtml: `<form action="/todo?id=1"></form>`,
+			givenDOM:     Form(Action("/todo?id=1"))(),
+		},
+		{
+			name:         "build script with javascript url source",
+			expectedHtml: `<script src="#ZgotmplZ"></script>`,
+			givenDOM:     Script(Src(" Java\tScript:alert(1)"))(),
+		},
+		{
+			name:         "build script with data url source by default policy",
+			expectedHtml: `<script src="#ZgotmplZ"></script>`,
+			givenDOM:     Script(Src("data:text/javascript,alert(1)"))(),
+		},
+		{
+			name:         "build script with data url source by custom policy",
+			expectedHtml: `<script src="data:text/javascript,alert(1)"></script>`,
+			givenDOM:     Script(Src("data:text/javascript,alert(1)"))(),
+			buildOpts:    []buildOpt{WithURLPolicy(AllowURLSchemes("https", "data"))},
+		},
+		{
+			name:         "build generated boolean and valued attributes",
+			expectedHtml: `<option selected disabled value="1" data-id="1">one</option>`,
+			givenDOM:     Option(Selected(), Disabled(), Value("1"), Dataset("id", "1"))(Text("one")),
+		},
+		{
+			name:         "build anchor with generated url attribute",
+			expectedHtml: `<a href="#ZgotmplZ" title="x">link</a>`,
+			givenDOM:     A(Href("javascript:void(0)"), TitleAttr("x"))(Text("link")),
+		},
+		{
+			name:         "build form with action and method",
+			expectedHtml: `<form action="/todo" method="post"><input type="submit"/></form>`,
+			givenDOM:     Form(Action("/todo"), Method("post"))(Input(Type("submit"))),
+		},
+		{
+			name:         "build single attribute with values",
+			expectedHtml: `<form method="post"></form>`,
+			givenDOM:     Form(Attr("method", Single, "post"))(),
+		},
+		{
+			name:        "build single attribute with values on strict mode",
+			givenDOM:    Form(Attr("method", Single, "post"))(),
+			buildOpts:   []buildOpt{WithStrictMode()},
+			expectedErr: ErrSingleWithValue,
+		},
+		{
+			name:         "build void element with children",
+			expectedHtml: `<input type="text"/>`,
+			givenDOM:     Tag("input", Void, Type("text"))(Text("ignored")),
+		},
+		{
+			name:        "build void element with children on strict mode",
+			givenDOM:    Tag("input", Void, Type("text"))(Text("ignored")),
+			buildOpts:   []buildOpt{WithStrictMode()},
+			expectedErr: ErrVoidWithChildren,
+		},
+		{
+			name:        "build empty tag name on strict mode",
+			givenDOM:    Div()(Tag("", NonVoid)()),
+			buildOpts:   []buildOpt{WithStrictMode()},
+			expectedErr: ErrInvalidTagName,
+		},
+		{
+			name:        "build table with div rows on validation",
+			givenDOM:    Table()(Div()(Tr()())),
+			buildOpts:   []buildOpt{WithValidation()},
+			expectedErr: ErrContentModel,
+		},
+		{
+			name:         "build fragment as root",
+			expectedHtml: `<tr id="1"></tr><tr id="2"></tr>`,
+			givenDOM:     Fragment(Tr(Id("1"))(), Tr(Id("2"))()),
+		},
+		{
+			name:         "build nested fragments",
+			expectedHtml: `<tbody><tr></tr>text<tr></tr></tbody>`,
+			givenDOM:     Tbody()(Fragment(Tr()(), Fragment(Text("text"), Tr()())), Fragment()),
+		},
+		{
+			name:         "build conditional contents",
+			expectedHtml: `<div><span>yes</span><b>no</b></div>`,
+			givenDOM: Div()(
+				If(true, Span()(Text("yes"))),
+				If(false, Span()(Text("hidden"))),
+				IfElse(false, I()(Text("yes")), B()(Text("no"))),
+			),
+		},
+		{
+			name:         "build mapped and ranged contents",
+			expectedHtml: `<ul><li>a</li><li>b</li></ul><ol><li value="0">a</li><li value="1">b</li></ol>`,
+			givenDOM: Fragment(
+				Ul()(Map([]string{"a", "b"}, func(s string) HTMLContent { return Li()(Text(s)) })),
+				Ol()(Range([]string{"a", "b"}, func(i int, s string) HTMLContent {
+					return Li(Value(strconv.Itoa(i)))(Text(s))
+				})),
+			),
+		},
+		{
+			name:         "build mapped empty slice",
+			expectedHtml: `<ul></ul>`,
+			givenDOM:     Ul()(Map([]string{}, func(s string) HTMLContent { return Li()(Text(s)) })),
+		},
+		{
+			name:         "build conditional attributes",
+			expectedHtml: `<input type="checkbox" class="off"/>`,
+			givenDOM:     Input(Type("checkbox"), IfElseAttr(false, ClassNames("on"), ClassNames("off")), IfAttr(false, Checked())),
+		},
+		{
+			name:         "build nothing attributes without stray spaces",
+			expectedHtml: `<input type="checkbox" id="1"/>`,
+			givenDOM:     Input(NothingAttr(), Type("checkbox"), IsChecked(false), Id("1"), NothingAttr()),
+		},
+		{
+			name: "build nothing contents without stray indentation",
+			expectedHtml: `<div>
+    <div></div>
+</div>`,
+			givenDOM:  Div()(Div()(Nothing(), If(false, Text("hidden")), Fragment(Nothing()))),
+			buildOpts: []buildOpt{WithDefaultIndentation()},
+		},
+		{
+			name:         "build void element with nothing on strict mode",
+			expectedHtml: `<input/>`,
+			givenDOM:     Tag("input", Void)(Nothing()),
+			buildOpts:    []buildOpt{WithStrictMode()},
+		},
+		{
+			name:         "build struct and function components",
+			expectedHtml: `<ul><li>one</li><div class="card"><h2>title</h2><p>body</p></div></ul>`,
+			givenDOM: Ul()(
+				Use(testItem{text: "one"}),
+				testCard("title", P()(Text("body"))),
+			),
+		},
+		{
+			name:         "build layout with slot defaults",
+			expectedHtml: `<div id="site"><header>default head</header><main></main><footer>default footer</footer></div>`,
+			givenDOM:     testSiteLayout.With(),
+		},
+		{
+			name:         "build layout with filled slots",
+			expectedHtml: `<div id="site"><header>head</header><main><p>a</p><p>b</p></main><footer>default footer</footer></div>`,
+			givenDOM:     testSiteLayout.With(Fill("head", Text("head")), Fill("main", P()(Text("a"))), Fill("main", P()(Text("b")))),
+		},
+		{
+			name:         "build nested layouts",
+			expectedHtml: `<div id="site"><header>head</header><main><aside>menu</aside><section>content</section></main><footer>section footer</footer></div>`,
+			givenDOM:     testSectionLayout.With(Fill("head", Text("head")), Fill("content", Text("content")), Fill("footer", Text("ignored"))),
+		},
+		{
+			name:         "build layout filled with another layout",
+			expectedHtml: `<div id="site"><header>default head</header><main><div id="site"><header>inner</header><main></main><footer>default footer</footer></div></main><footer>default footer</footer></div>`,
+			givenDOM:     testSiteLayout.With(Fill("main", testSiteLayout.With(Fill("head", Text("inner"))))),
+		},
+		{
+			name:         "build slot out of layouts",
+			expectedHtml: `<p>default</p>`,
+			givenDOM:     P()(Yield("main", Text("default"))),
+		},
 		/* HTMX attributes tests */
 		{
 			name:         "build input with htmx attributes",
 			expectedHtml: `<input checked hx-on:click="console.log('hello')"/>`,
 			givenDOM:     Input(Checked(), HxOn("click", "console.log('hello')")),
 		},
+		{
+			name:         "build div with htmx url attributes",
+			expectedHtml: `<div hx-get="https://example.com/todo" hx-post="#ZgotmplZ"></div>`,
+			givenDOM:     Div(HxGet("https://example.com/todo"), HxPost("vbscript:msgbox"))(),
+		},
 		// TODO: fix wrong attribute spaces sort
 		// i.g.: <input type="checkbox"required required="required"/>
+		{
+			name:         "build minified lists without optional end tags",
+			expectedHtml: `<ul><li>a<li>b</ul><select><option>a<option>b</select>`,
+			givenDOM: Fragment(
+				Ul()(Li()(Text("a")), Li()(Text("b"))),
+				Select()(Option()(Text("a")), Nothing(), Option()(Text("b"))),
+			),
+			buildOpts: []buildOpt{WithMinify()},
+		},
+		{
+			name:         "build minified table without optional end tags",
+			expectedHtml: `<table><thead><tr><th>h<tbody><tr><td>1<td>2<tr><td>3</table>`,
+			givenDOM: Table()(
+				Thead()(Tr()(Th()(Text("h")))),
+				Tbody()(Tr()(Td()(Text("1")), Td()(Text("2"))), Tr()(Td()(Text("3")))),
+			),
+			buildOpts: []buildOpt{WithMinify()},
+		},
+		{
+			name:         "build minified paragraphs keeping the needed end tags",
+			expectedHtml: `<div><p>a<p>b</p><a href=/><p>c</p></a><p>d</div>`,
+			givenDOM: Div()(
+				P()(Text("a")),
+				P()(Text("b")),
+				A(Href("/"))(P()(Text("c"))),
+				P()(Text("d")),
+			),
+			buildOpts: []buildOpt{WithMinify()},
+		},
+		{
+			name:         "build minified contents keeping the end tags before dynamic contents",
+			expectedHtml: `<ul><li>a</li><li>b</li></ul>`,
+			givenDOM:     Ul()(Li()(Text("a")), Use(testItem{text: "b"})),
+			buildOpts:    []buildOpt{WithMinify()},
+		},
+		{
+			name:         "build minified document",
+			expectedHtml: `<!DOCTYPE html><html lang=en><head><title> t </title><body class="a m z"><div id=main>hello world</div>`,
+			givenDOM: Html(Lang("en"))(
+				Head()(Title()(Text(" t "))),
+				Body(ClassNames("z a", "m a"))(Div(Id("main"))(Text("  hello \n  world  "))),
+			),
+			buildOpts: []buildOpt{WithMinify()},
+		},
+		{
+			name:         "build minified whitespace keeping preformatted texts",
+			expectedHtml: `<div>a <b> b c </b> d<pre>  keep ` + "\n" + ` this </pre><textarea> x  y </textarea><script> if (a  &&  b) {} </script></div>`,
+			givenDOM: Div()(
+				Text("  a  "),
+				B()(Text(" b  c ")),
+				Text("  d "),
+				Pre()(Text("  keep \n this ")),
+				Textarea()(Text(" x  y ")),
+				Script()(RawText(" if (a  &&  b) {} ")),
+			),
+			buildOpts: []buildOpt{WithMinify()},
+		},
+		{
+			name:         "build minified whitespace around inline and unknown elements",
+			expectedHtml: `<div><p>Hello <my-user>ana</my-user>!<p>a <script></script> b<p>c <span>d</span> e</div>`,
+			givenDOM: Div()(
+				P()(Text("Hello "), Tag("my-user", NonVoid)(Text("ana")), Text("!")),
+				P()(Text("a "), Script()(), Text(" b")),
+				P()(Text("c "), Cached("d", time.Minute, func() HTMLContent { return Span()(Text("d")) }), Text(" e")),
+			),
+			buildOpts: []buildOpt{WithMinify()},
+		},
+		{
+			name:         "build minified attributes unquoting the safe values",
+			expectedHtml: `<input type=text value="a b" name=q data-x="" disabled><a href="/a?b=1&amp;c=2" title=a&amp;b></a>`,
+			givenDOM: Fragment(
+				Input(Type("text"), Value("a b"), Name("q"), Dataset("x", ""), Disabled()),
+				A(Href("/a?b=1&c=2"), TitleAttr("a&b"))(),
+			),
+			buildOpts: []buildOpt{WithMinify()},
+		},
+		{
+			name:         "build minified svg keeping the self-closed elements",
+			expectedHtml: `<div><svg viewBox="0 0 2 2"><circle r="1"/><rect x="0"/><foreignObject><p>a</foreignObject></svg><br></div>`,
+			givenDOM: Div()(
+				Svg(Attr("viewBox", DoubleQuoted, "0 0 2 2"))(
+					Tag("circle", Void, Attr("r", DoubleQuoted, "1"))(),
+					Tag("rect", Void, Attr("x", DoubleQuoted, "0"))(),
+					Tag("foreignObject", NonVoid)(P()(Text("a"))),
+				),
+				Br(),
+			),
+			buildOpts: []buildOpt{WithMinify()},
+		},
+		{
+			name:         "build minified output replacing the indentation",
+			expectedHtml: `<div><div>a</div></div>`,
+			givenDOM:     Div()(Div()(Text("a"))),
+			buildOpts:    []buildOpt{WithDefaultIndentation(), WithMinify()},
+		},
 		// {
 		// 	name:         "build all boolean attributes possibilities",
 		// 	expectedHtml: `<input type="text" required required="required"/>`,
@@ -116,6 +440,12 @@ func TestBasicDOM(t *testing.T) {
 			opts = append(opts, tc.buildOpts...)
 
 			err := tc.givenDOM.BuildDOM(opts...)
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Errorf("error not match: given: [%v], expected: [%v]", err, tc.expectedErr)
+				}
+				return
+			}
 			if err != nil {
 				t.Error(err)
 			}
@@ -126,3 +456,883 @@ func TestBasicDOM(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildErrorPath(t *testing.T) {
+	dom := Html()(
+		Head()(),
+		Body()(
+			Div()(Text("a"), Tag("input", Void)(Text("b"))),
+		),
+	)
+
+	err := dom.BuildDOM(WithWriter(new(strings.Builder)), WithStrictMode())
+
+	var bErr *BuildError
+	if !errors.As(err, &bErr) {
+		t.Fatalf("expected a build error, given: [%v]", err)
+	}
+	if expected := "html > body[1] > div[0] > input[1]"; bErr.Path != expected {
+		t.Errorf("path not match: given: [%s], expected: [%s]", bErr.Path, expected)
+	}
+	if bErr.TagName != "input" {
+		t.Errorf("tag name not match: given: [%s], expected: [input]", bErr.TagName)
+	}
+
+	err = Ul()(Use(&testItem{}), testCard("title", Tag("br", Void)(Text("b")))).
+		BuildDOM(WithWriter(new(strings.Builder)), WithStrictMode())
+	if !errors.As(err, &bErr) {
+		t.Fatalf("expected a build error, given: [%v]", err)
+	}
+	if expected := "ul > @Card[1] > div > #fragment[1] > br[0]"; bErr.Path != expected {
+		t.Errorf("path not match: given: [%s], expected: [%s]", bErr.Path, expected)
+	}
+}
+
+func TestNilFunctions(t *testing.T) {
+	testSuite := []struct {
+		name         string
+		givenDOM     HTMLContent
+		buildOpts    []buildOpt
+		expectedErr  error
+		expectedPath string
+	}{
+		{name: "nil component", givenDOM: Div()(Use(nil)), expectedErr: ErrNilComponent, expectedPath: "div"},
+		{
+			name:         "nil component function",
+			givenDOM:     Div()(NewComponent[string]("Card", nil)("title")),
+			expectedErr:  ErrNilFunc,
+			expectedPath: "div > @Card[0]",
+		},
+		{name: "nil consumer", givenDOM: Div()(Consume(nil)), expectedErr: ErrNilFunc, expectedPath: "div > #consumer[0]"},
+		{name: "nil async", givenDOM: Div()(Async(Text("..."), nil)), expectedErr: ErrNilFunc, expectedPath: "div > #async[0]"},
+		{name: "nil lazy", givenDOM: Div()(Lazy(nil)), expectedErr: ErrNilFunc, expectedPath: "div > #lazy[0]"},
+		{
+			name:         "nil lazy with concurrency",
+			givenDOM:     Div()(Lazy(nil)),
+			buildOpts:    []buildOpt{WithConcurrency(2)},
+			expectedErr:  ErrNilFunc,
+			expectedPath: "div > #lazy[0]",
+		},
+		{
+			name:         "nil cached",
+			givenDOM:     Div()(Cached("k", time.Minute, nil)),
+			buildOpts:    []buildOpt{WithCache(NewMemoryCache(1))},
+			expectedErr:  ErrNilFunc,
+			expectedPath: "div > #cached(k)[0]",
+		},
+	}
+
+	for _, tc := range testSuite {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.givenDOM.BuildDOM(append(tc.buildOpts, WithWriter(new(strings.Builder)))...)
+
+			var bErr *BuildError
+			if !errors.As(err, &bErr) || !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("error not match: given: [%v], expected: [%v]", err, tc.expectedErr)
+			}
+			if bErr.Path != tc.expectedPath {
+				t.Errorf("path not match: given: [%s], expected: [%s]", bErr.Path, tc.expectedPath)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testSuite := []struct {
+		name          string
+		givenDOM      HTMLContent
+		expectedPaths []string
+	}{
+		{
+			name: "validate well formed document",
+			givenDOM: Html()(
+				Head()(Title()(Text("title")), Script(Src("index.js"))()),
+				Body()(
+					Form(Action("/todo"))(Label()(Text("task"), Input(Type("text")))),
+					Table()(Tbody()(Tr()(Td()(Div()(Text("cell")))))),
+					P()(A(Href("/"))(Span()(Text("home")))),
+					Ul()(Li()(Div()())),
+				),
+			),
+		},
+		{
+			name: "validate table and list children",
+			givenDOM: Div()(
+				Table()(Div()(Tr()())),
+				Ul()(Div()()),
+				Tr()(Td()()),
+			),
+			expectedPaths: []string{"div > table[0] > div[0]", "div > table[0] > div[0] > tr[0]", "div > ul[1] > div[0]", "div > tr[2]"},
+		},
+		{
+			name:          "validate flow content in phrasing context",
+			givenDOM:      P()(Span()(Div()())),
+			expectedPaths: []string{"p > span[0] > div[0]"},
+		},
+		{
+			name:          "validate transparent content in phrasing context",
+			givenDOM:      P()(A()(Div()())),
+			expectedPaths: []string{"p > a[0] > div[0]"},
+		},
+		{
+			name:          "validate nested interactive content",
+			givenDOM:      A(Href("/"))(Button()(Text("click")), Input(Type("hidden"))),
+			expectedPaths: []string{"a > button[0]"},
+		},
+		{
+			name:          "validate fragment contents against the parent",
+			givenDOM:      Div()(Fragment(Tr()()), Table()(Fragment(Tr()()))),
+			expectedPaths: []string{"div > #fragment[0] > tr[0]"},
+		},
+		{
+			name:          "validate filled layout slots",
+			givenDOM:      NewLayout("Table", Table()(Yield("rows"))).With(Fill("rows", Tr()(), Div()())),
+			expectedPaths: []string{"@Table > table > #slot(rows)[0] > div[1]"},
+		},
+		{
+			name: "validate async fallbacks without the lazy contents",
+			givenDOM: Table()(
+				Async(Div()(), func(context.Context) (HTMLContent, error) { return Tr()(), nil }),
+				Lazy(func(context.Context) (HTMLContent, error) { return Div()(), nil }),
+			),
+			expectedPaths: []string{"table > #async[0] > div"},
+		},
+		{
+			name:     "validate skipping components without render function",
+			givenDOM: Table()(NewComponent[string]("Card", nil)("title")),
+		},
+		{
+			name:          "validate text in table rows",
+			givenDOM:      Tr()(Text(" "), Text("text")),
+			expectedPaths: []string{"tr"},
+		},
+	}
+
+	for _, tc := range testSuite {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.givenDOM)
+			if len(tc.expectedPaths) == 0 {
+				if err != nil {
+					t.Errorf("unexpected violations: [%v]", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected violations at %q", tc.expectedPaths)
+			}
+
+			var paths []string
+			for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+				var bErr *BuildError
+				if !errors.As(e, &bErr) || !errors.Is(e, ErrContentModel) {
+					t.Fatalf("expected a content model error, given: [%v]", e)
+				}
+				paths = append(paths, bErr.Path)
+			}
+			if strings.Join(paths, ", ") != strings.Join(tc.expectedPaths, ", ") {
+				t.Errorf("violations not match: given: %q, expected: %q", paths, tc.expectedPaths)
+			}
+		})
+	}
+}
+
+func TestUniqueIDs(t *testing.T) {
+	dom := Div()(
+		Table()(Tbody(Id("todo-list"))()),
+		Div(Id("todo-list"))(),
+	)
+
+	logs := new(strings.Builder)
+	logger := slog.New(slog.NewTextHandler(logs, nil))
+
+	err := dom.BuildDOM(WithWriter(new(strings.Builder)), WithLogger(logger), WithUniqueIDs())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(logs.String(), `first_path="div > table[0] > tbody[0]" second_path="div > div[1]"`) {
+		t.Errorf("expected a duplicated id warning, given: [%s]", logs.String())
+	}
+
+	err = dom.BuildDOM(WithWriter(new(strings.Builder)), WithUniqueIDs(), WithStrictMode())
+	var idErr *DuplicateIDError
+	if !errors.As(err, &idErr) {
+		t.Fatalf("expected a duplicated id error, given: [%v]", err)
+	}
+	if idErr.ID != "todo-list" || idErr.FirstPath != "div > table[0] > tbody[0]" || idErr.SecondPath != "div > div[1]" {
+		t.Errorf("unexpected duplicated id error: [%v]", idErr)
+	}
+}
+
+type testCtxKey string
+
+func TestBuildDOMContext(t *testing.T) {
+	greeting := Consume(func(ctx context.Context) HTMLContent {
+		user, _ := ctx.Value(testCtxKey("user")).(string)
+		locale, _ := ctx.Value(testCtxKey("locale")).(string)
+		return Span()(Text(locale + ":" + user))
+	})
+
+	dom := Div()(
+		greeting,
+		Provide(testCtxKey("user"), "ana",
+			greeting,
+			Provide(testCtxKey("user"), "bob", P()(greeting)),
+			greeting,
+		),
+		greeting,
+	)
+
+	st := new(strings.Builder)
+	ctx := context.WithValue(context.Background(), testCtxKey("locale"), "pt")
+	if err := dom.BuildDOMContext(ctx, WithWriter(st), WithValidation()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<div><span>pt:</span><span>pt:ana</span><p><span>pt:bob</span></p><span>pt:ana</span><span>pt:</span></div>`
+	if st.String() != expected {
+		t.Errorf("result not match: given: [%s], expected: [%s]", st.String(), expected)
+	}
+}
+
+func TestBuildDOMContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dom := Table()(Tbody()(
+		Tr()(Td()(Text("1"))),
+		Consume(func(context.Context) HTMLContent {
+			// i.g.: the client disconnected
+			cancel()
+			return Nothing()
+		}),
+		Tr()(Td()(Text("2"))),
+	))
+
+	st := new(strings.Builder)
+	err := dom.BuildDOMContext(ctx, WithWriter(st))
+
+	var bErr *BuildError
+	if !errors.As(err, &bErr) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a canceled build error, given: [%v]", err)
+	}
+	if expected := "table > tbody[0] > tr[2]"; bErr.Path != expected {
+		t.Errorf("path not match: given: [%s], expected: [%s]", bErr.Path, expected)
+	}
+	if expected := "<table><tbody><tr><td>1</td></tr>"; st.String() != expected {
+		t.Errorf("result not match: given: [%s], expected: [%s]", st.String(), expected)
+	}
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+	if err := dom.BuildDOMContext(ctx, WithWriter(new(strings.Builder))); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline exceeded error, given: [%v]", err)
+	}
+}
+
+// records what was written until each flush
+type testFlusher struct {
+	strings.Builder
+	flushes []string
+}
+
+func (f *testFlusher) Flush() {
+	f.flushes = append(f.flushes, f.String())
+}
+
+func TestFlush(t *testing.T) {
+	dom := Html()(
+		Head()(Script(Src("index.js"))()),
+		Body()(
+			Div()(Text("first"), Flush()),
+			Hr(),
+			Div()(Text("second")),
+		),
+	)
+
+	w := new(testFlusher)
+	if err := dom.BuildDOM(WithWriter(w), WithFlushAfter("head", "hr")); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"<!DOCTYPE html><html><head><script src=\"index.js\"></script></head>",
+		"<!DOCTYPE html><html><head><script src=\"index.js\"></script></head><body><div>first",
+		"<!DOCTYPE html><html><head><script src=\"index.js\"></script></head><body><div>first</div><hr/>",
+	}
+	if strings.Join(w.flushes, "|") != strings.Join(expected, "|") {
+		t.Errorf("flushes not match: given: %q, expected: %q", w.flushes, expected)
+	}
+}
+
+// calls the hook on every write
+type testHookWriter struct {
+	strings.Builder
+	hook func(s string)
+}
+
+func (w *testHookWriter) Write(p []byte) (int, error) {
+	w.hook(string(p))
+	return w.Builder.Write(p)
+}
+
+func TestAsync(t *testing.T) {
+	secondWritten := make(chan struct{})
+	w := &testHookWriter{hook: func(s string) {
+		if strings.Contains(s, "gomlSwap(1)") {
+			close(secondWritten)
+		}
+	}}
+
+	dom := Ul()(
+		Async(Li()(Text("loading first")), func(ctx context.Context) (HTMLContent, error) {
+			// resolves after the second one is written
+			<-secondWritten
+			return Li()(Text("first")), nil
+		}),
+		Provide(testCtxKey("user"), "ana",
+			Async(Li()(Text("loading second")), func(ctx context.Context) (HTMLContent, error) {
+				return Li()(Consume(func(ctx context.Context) HTMLContent {
+					return Text(ctx.Value(testCtxKey("user")).(string))
+				})), nil
+			}),
+		),
+		Li()(Text("static")),
+	)
+
+	if err := dom.BuildDOM(WithWriter(w)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<ul>` +
+		`<template id="goml-ph-0"></template><li>loading first</li><!--/goml-ph-0-->` +
+		`<template id="goml-ph-1"></template><li>loading second</li><!--/goml-ph-1-->` +
+		`<li>static</li></ul>` + asyncSwapScript +
+		`<template id="goml-as-1"><li>ana</li></template><script>gomlSwap(1)</script>` +
+		`<template id="goml-as-0"><li>first</li></template><script>gomlSwap(0)</script>`
+	if w.String() != expected {
+		t.Errorf("result not match: given: [%s], expected: [%s]", w.String(), expected)
+	}
+}
+
+func TestAsyncError(t *testing.T) {
+	errNotFound := errors.New("not found")
+	dom := Div()(
+		Async(Text("loading"), func(ctx context.Context) (HTMLContent, error) {
+			return Nothing(), errNotFound
+		}),
+		Async(Text("loading"), func(ctx context.Context) (HTMLContent, error) {
+			return Text("done"), nil
+		}),
+	)
+
+	st := new(strings.Builder)
+	err := dom.BuildDOM(WithWriter(st))
+
+	var bErr *BuildError
+	if !errors.As(err, &bErr) || !errors.Is(err, errNotFound) {
+		t.Fatalf("expected an async build error, given: [%v]", err)
+	}
+	if expected := "div > #async[0]"; bErr.Path != expected {
+		t.Errorf("path not match: given: [%s], expected: [%s]", bErr.Path, expected)
+	}
+	if !strings.HasSuffix(st.String(), `<template id="goml-as-1">done</template><script>gomlSwap(1)</script>`) {
+		t.Errorf("expected the resolved content to be written, given: [%s]", st.String())
+	}
+}
+
+func TestLazyConcurrency(t *testing.T) {
+	const rows = 8
+	var mu sync.Mutex
+	var running, maxRunning int
+
+	row := func(i int) HTMLContent {
+		return Lazy(func(ctx context.Context) (HTMLContent, error) {
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				running--
+				mu.Unlock()
+			}()
+			// the last rows resolve first
+			time.Sleep(time.Duration(rows-i) * time.Millisecond)
+			return Tr()(Td()(Text(strconv.Itoa(i)), Text(ctx.Value(testCtxKey("user")).(string)))), nil
+		})
+	}
+
+	var expected strings.Builder
+	var contents []HTMLContent
+	expected.WriteString("<table><tbody>")
+	for i := 0; i < rows; i++ {
+		contents = append(contents, row(i))
+		expected.WriteString("<tr><td>" + strconv.Itoa(i) + "ana</td></tr>")
+	}
+	expected.WriteString("</tbody></table>")
+
+	dom := Provide(testCtxKey("user"), "ana", Table()(Tbody()(contents...)))
+
+	st := new(strings.Builder)
+	if err := dom.BuildDOM(WithWriter(st), WithConcurrency(3)); err != nil {
+		t.Fatal(err)
+	}
+	if st.String() != expected.String() {
+		t.Errorf("result not match: given: [%s], expected: [%s]", st.String(), expected.String())
+	}
+	if maxRunning < 2 || maxRunning > 3 {
+		t.Errorf("expected up to 3 concurrent rows, given: [%d]", maxRunning)
+	}
+}
+
+func TestLazyReused(t *testing.T) {
+	user := Lazy(func(ctx context.Context) (HTMLContent, error) {
+		return Text(ctx.Value(testCtxKey("user")).(string)), nil
+	})
+	dom := P()(
+		Provide(testCtxKey("user"), "ana", user),
+		Provide(testCtxKey("user"), "bob", user),
+	)
+
+	// each occurrence gets its own provided values, on both modes
+	for _, opts := range [][]buildOpt{nil, {WithConcurrency(2)}} {
+		st := new(strings.Builder)
+		if err := dom.BuildDOM(append(opts, WithWriter(st))...); err != nil {
+			t.Fatal(err)
+		}
+		if expected := "<p>anabob</p>"; st.String() != expected {
+			t.Errorf("result not match: given: [%s], expected: [%s]", st.String(), expected)
+		}
+	}
+}
+
+func TestLazyErrors(t *testing.T) {
+	errDB := errors.New("db is down")
+	started, canceled := make(chan struct{}), make(chan struct{})
+
+	dom := Ul()(
+		Li()(Lazy(func(ctx context.Context) (HTMLContent, error) {
+			close(started)
+			<-ctx.Done()
+			close(canceled)
+			return Nothing(), ctx.Err()
+		})),
+		Li()(Lazy(func(ctx context.Context) (HTMLContent, error) {
+			<-started
+			return Nothing(), errDB
+		})),
+	)
+
+	err := dom.BuildDOM(WithWriter(new(strings.Builder)), WithConcurrency(2))
+
+	var bErr *BuildError
+	if !errors.As(err, &bErr) || !errors.Is(err, errDB) {
+		t.Fatalf("expected a lazy build error, given: [%v]", err)
+	}
+	if expected := "ul > li[1] > #lazy[0]"; bErr.Path != expected {
+		t.Errorf("path not match: given: [%s], expected: [%s]", bErr.Path, expected)
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Errorf("canceled rows shouldn't be reported, given: [%v]", err)
+	}
+	select {
+	case <-canceled:
+	default:
+		t.Error("expected the other rows to be canceled")
+	}
+
+	// without concurrency each row is resolved when it's reached
+	err = Ul()(Lazy(func(ctx context.Context) (HTMLContent, error) {
+		return Nothing(), errDB
+	})).BuildDOM(WithWriter(new(strings.Builder)))
+	if !errors.Is(err, errDB) {
+		t.Errorf("expected a lazy build error, given: [%v]", err)
+	}
+}
+
+func TestRenderResult(t *testing.T) {
+	dom := testSiteLayout.With(
+		Fill("main", Ul()(Use(testItem{text: "a"}), Use(testItem{text: "b"}))),
+		Fill("footer", testCard("card")),
+	)
+
+	st := new(strings.Builder)
+	result, err := Render(context.Background(), dom, WithWriter(st))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.BytesWritten != st.Len() {
+		t.Errorf("bytes not match: given: [%d], expected: [%d]", result.BytesWritten, st.Len())
+	}
+	// div, header, main, ul, li, li, footer, div, h2
+	if result.Elements != 9 {
+		t.Errorf("elements not match: given: [%d], expected: [9]", result.Elements)
+	}
+	// div > main > ul > li
+	if result.MaxDepth != 4 {
+		t.Errorf("max depth not match: given: [%d], expected: [4]", result.MaxDepth)
+	}
+	if result.Duration <= 0 {
+		t.Errorf("expected a build duration, given: [%s]", result.Duration)
+	}
+
+	counts := make(map[string]int)
+	for name, timing := range result.Components {
+		counts[name] = timing.Count
+	}
+	if expected := map[string]int{"Site": 1, "testItem": 2, "Card": 1}; !reflect.DeepEqual(counts, expected) {
+		t.Errorf("components not match: given: [%v], expected: [%v]", counts, expected)
+	}
+}
+
+func TestCompile(t *testing.T) {
+	page := func(body HTMLContent) HTMLContent {
+		return Html(Lang("en"))(
+			Head()(Title()(Yield("title", Text("default title")))),
+			Body(ClassNames("main"))(
+				Div(Id("menu"))(A(Href("/"))(Text("home")), Flush()),
+				body,
+				Provide(testCtxKey("user"), "ana", Consume(func(ctx context.Context) HTMLContent {
+					return P()(Text(ctx.Value(testCtxKey("user")).(string)))
+				})),
+				Footer()(Yield("footer")),
+			),
+		)
+	}
+	body := Ul()(Use(testItem{text: "a"}), Use(testItem{text: "b"}))
+	fills := []SlotFill{Fill("title", Text("compiled")), Fill("footer", testCard("card"))}
+
+	testSuite := []struct {
+		name string
+		opts []buildOpt
+	}{
+		{name: "compile without indentation"},
+		{name: "compile with indentation", opts: []buildOpt{WithDefaultIndentation()}},
+		{name: "compile with flush after", opts: []buildOpt{WithFlushAfter("head")}},
+		{name: "compile with minify", opts: []buildOpt{WithMinify()}},
+	}
+	for _, tc := range testSuite {
+		t.Run(tc.name, func(t *testing.T) {
+			compiled, err := Compile(page(body), tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			build := func(ct HTMLContent) (*testFlusher, RenderResult) {
+				w := new(testFlusher)
+				opts := append([]buildOpt{WithWriter(w)}, tc.opts...)
+				result, err := Render(context.Background(), NewLayout("Page", ct).With(fills...), opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return w, result
+			}
+
+			// each build gets the same output of the uncompiled content
+			expected, expectedResult := build(page(body))
+			for i := 0; i < 2; i++ {
+				given, result := build(compiled)
+				if given.String() != expected.String() {
+					t.Errorf("result not match: given: [%s], expected: [%s]", given.String(), expected.String())
+				}
+				if !reflect.DeepEqual(given.flushes, expected.flushes) {
+					t.Errorf("flushes not match: given: %q, expected: %q", given.flushes, expected.flushes)
+				}
+				if result.Elements != expectedResult.Elements || result.MaxDepth != expectedResult.MaxDepth {
+					t.Errorf("metrics not match: given: [%d, %d], expected: [%d, %d]",
+						result.Elements, result.MaxDepth, expectedResult.Elements, expectedResult.MaxDepth)
+				}
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	if _, err := Compile(Div()(Tag("br", Void)(Text("b"))), WithStrictMode()); !errors.Is(err, ErrVoidWithChildren) {
+		t.Errorf("expected a compile error, given: [%v]", err)
+	}
+
+	// holes are built with the path of the compiled content
+	compiled := MustCompile(Div(Id("menu"))(Section()(Consume(func(context.Context) HTMLContent {
+		return Tag("br", Void)(Text("b"))
+	}))))
+	err := Main()(Text("a"), compiled).BuildDOM(WithWriter(new(strings.Builder)), WithStrictMode())
+
+	var bErr *BuildError
+	if !errors.As(err, &bErr) {
+		t.Fatalf("expected a build error, given: [%v]", err)
+	}
+	if expected := "main > #compiled[1] > div > section[0] > #consumer[0] > br"; bErr.Path != expected {
+		t.Errorf("path not match: given: [%s], expected: [%s]", bErr.Path, expected)
+	}
+
+	// ids of the compiled content are still tracked
+	compiled = MustCompile(Div(Id("menu"))(Text("menu")))
+	err = Fragment(compiled, Div(Id("menu"))()).BuildDOM(WithWriter(new(strings.Builder)), WithUniqueIDs(), WithStrictMode())
+	var idErr *DuplicateIDError
+	if !errors.As(err, &idErr) {
+		t.Fatalf("expected a duplicated id error, given: [%v]", err)
+	}
+	if expected := "#fragment > #compiled[0] > div"; idErr.FirstPath != expected {
+		t.Errorf("path not match: given: [%s], expected: [%s]", idErr.FirstPath, expected)
+	}
+}
+
+func TestCached(t *testing.T) {
+	cache := NewMemoryCache(2)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	var builds int
+	row := func(key string, text string) HTMLContent {
+		return Cached(key, time.Minute, func() HTMLContent {
+			builds++
+			return Li(ClassNames("row"))(Text(text), Flush())
+		})
+	}
+	build := func(contents ...HTMLContent) string {
+		t.Helper()
+		w := new(testFlusher)
+		if err := Ul()(contents...).BuildDOM(WithWriter(w), WithCache(cache)); err != nil {
+			t.Fatal(err)
+		}
+		return w.String()
+	}
+
+	expected := `<ul><li class="row">a</li><li class="row">b</li></ul>`
+	if given := build(row("a", "a"), row("b", "b")); given != expected {
+		t.Errorf("result not match: given: [%s], expected: [%s]", given, expected)
+	}
+	// the stored bytes are written, even when the content changes
+	if given := build(row("a", "changed"), row("b", "b")); given != expected || builds != 2 {
+		t.Errorf("expected the cached rows: given: [%s] with [%d] builds", given, builds)
+	}
+
+	// invalidated, expired and evicted keys are built again
+	cache.Delete("a")
+	if given := build(row("a", "changed")); given != `<ul><li class="row">changed</li></ul>` || builds != 3 {
+		t.Errorf("expected the invalidated row: given: [%s] with [%d] builds", given, builds)
+	}
+	now = now.Add(time.Minute)
+	build(row("a", "a"))
+	build(row("c", "c"))
+	if builds != 5 {
+		t.Errorf("expected the expired row to be built, given: [%d] builds", builds)
+	}
+	build(row("b", "b"))
+	if builds != 6 {
+		t.Errorf("expected the evicted row to be built, given: [%d] builds", builds)
+	}
+
+	expectedStats := CacheStats{Hits: 2, Misses: 6, Evictions: 2, Entries: 2}
+	if stats := cache.Stats(); stats != expectedStats {
+		t.Errorf("stats not match: given: [%+v], expected: [%+v]", stats, expectedStats)
+	}
+
+	// without a cache the contents are always built
+	if err := row("a", "a").BuildDOM(WithWriter(new(strings.Builder))); err != nil || builds != 7 {
+		t.Errorf("expected the row to be built, given: [%v] with [%d] builds", err, builds)
+	}
+
+	// failed contents aren't stored
+	err := Cached("bad", 0, func() HTMLContent { return Tag("br", Void)(Text("b")) }).
+		BuildDOM(WithWriter(new(strings.Builder)), WithCache(cache), WithStrictMode())
+	if !errors.Is(err, ErrVoidWithChildren) {
+		t.Errorf("expected a build error, given: [%v]", err)
+	}
+	if _, ok := cache.Get("bad"); ok {
+		t.Error("expected the failed content to not be cached")
+	}
+
+	err = Cached("async", 0, func() HTMLContent {
+		return Async(Nothing(), func(ctx context.Context) (HTMLContent, error) { return Nothing(), nil })
+	}).BuildDOM(WithWriter(new(strings.Builder)))
+	if !errors.Is(err, ErrCachedAsync) {
+		t.Errorf("expected a cached async error, given: [%v]", err)
+	}
+}
+
+func TestCachedIndentation(t *testing.T) {
+	row := Cached("row", 0, func() HTMLContent {
+		return Tr()(Td()(Text("x")), Td()(Pre()(Text("a\n  b"))))
+	})
+	item := Cached("item", 0, func() HTMLContent { return Li()(Div()(Text("b"))) })
+	list := Cached("list", 0, func() HTMLContent { return Ul()(Li()(Div()(Text("a"))), item) })
+
+	// each entry is reused at another depth, so it's written indented as
+	// if it wasn't cached
+	testSuite := []HTMLContent{
+		Table()(Tbody()(row)),
+		Tbody()(row),
+		Div()(Section()(Table()(row))),
+		Div()(list),
+		Fragment(Ul()(item), Main()(Section()(list))),
+	}
+
+	cache := NewMemoryCache(10)
+	for i, dom := range testSuite {
+		expected := new(strings.Builder)
+		if err := dom.BuildDOM(WithWriter(expected), WithDefaultIndentation()); err != nil {
+			t.Fatal(err)
+		}
+		st := new(strings.Builder)
+		if err := dom.BuildDOM(WithWriter(st), WithDefaultIndentation(), WithCache(cache)); err != nil {
+			t.Fatal(err)
+		}
+		if st.String() != expected.String() {
+			t.Errorf("result %d not match: given: [%s], expected: [%s]", i, st.String(), expected.String())
+		}
+	}
+	if stats := cache.Stats(); stats.Hits != 4 {
+		t.Errorf("expected the entries to be reused, given: [%+v]", stats)
+	}
+}
+
+var updateGolden = flag.Bool("update", false, "update the golden files")
+
+func TestPrettyPrint(t *testing.T) {
+	testSuite := []struct {
+		name     string
+		givenDOM HTMLContent
+	}{
+		{
+			name: "document",
+			givenDOM: Html(Lang("en"))(
+				Head()(
+					Meta(Charset("utf-8")),
+					Title()(Text("Todo List")),
+					Script()(RawText("\n  if (x) {\n    run()\n  }\n")),
+				),
+				Body()(
+					Header()(Nav()(Ul()(
+						Li()(A(Href("/"))(Text("home"))),
+						Li()(A(Href("/about"))(Text("about"))),
+					))),
+					Main()(
+						H1()(Text("Todos")),
+						P()(Text("Hello "), B()(Text("world")), Text("!")),
+						Pre()(Text("func main() {\n\tprintln()\n}")),
+						Textarea()(Text("  keep\n  me  ")),
+					),
+				),
+			),
+		},
+		{
+			name: "mixed contents",
+			givenDOM: Div()(
+				Text("intro "),
+				Span()(Text("inline")),
+				Div()(Text("block")),
+				RawText("<b>raw</b>"),
+				Text(" outro"),
+				Hr(),
+				Img(Src("a.png")),
+			),
+		},
+		{
+			name: "inline elements holding blocks",
+			givenDOM: Section()(
+				A(Href("/"))(Div()(Text("card")), P()(Text("text"))),
+				Button()(Span()(Text("a")), Text(" "), Span()(Text("b"))),
+			),
+		},
+		{
+			name: "fragments and dynamic contents",
+			givenDOM: Fragment(
+				Table()(Tbody()(
+					Tr()(Td()(Text("1")), Td()(Text("one"))),
+					Fragment(Tr()(Td()(Text("2")))),
+				)),
+				testSiteLayout.With(Fill("main", Ul()(Use(testItem{text: "a"})))),
+				P()(Consume(func(context.Context) HTMLContent { return Text("consumed") })),
+			),
+		},
+		{
+			name: "custom elements",
+			givenDOM: Section()(
+				P()(Text("Hello "), Tag("my-user", NonVoid)(Text("ana")), Text("!")),
+				P()(Text("a "), Script()(), Text(" "), Tag("slot", NonVoid)(), Text(" b")),
+				Tag("my-card", NonVoid)(Div()(Text("card"))),
+			),
+		},
+		{
+			name: "surrounding whitespace",
+			givenDOM: Div()(
+				Text("\n    padded  "),
+				Em()(Text(" em ")),
+				Text("   "),
+				Strong()(Text("strong")),
+				Text("  \n"),
+				Div()(Text("  block  ")),
+				Text(" \t "),
+			),
+		},
+	}
+
+	for _, tc := range testSuite {
+		t.Run(tc.name, func(t *testing.T) {
+			st := new(strings.Builder)
+			if err := tc.givenDOM.BuildDOM(WithWriter(st), WithDefaultIndentation()); err != nil {
+				t.Fatal(err)
+			}
+
+			golden := filepath.Join("testdata", "pretty", strings.ReplaceAll(tc.name, " ", "_")+".golden")
+			if *updateGolden {
+				if err := os.WriteFile(golden, []byte(st.String()), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if st.String() != string(expected) {
+				t.Errorf("result not match: given:\n%s\nexpected:\n%s", st.String(), expected)
+			}
+
+			// the whitespace of the printed document doesn't change it
+			again := new(strings.Builder)
+			if err := withLineBreaks(tc.givenDOM).BuildDOM(WithWriter(again), WithDefaultIndentation()); err != nil {
+				t.Fatal(err)
+			}
+			if again.String() != st.String() {
+				t.Errorf("not idempotent: given:\n%s\nexpected:\n%s", again.String(), st.String())
+			}
+		})
+	}
+}
+
+// withLineBreaks adds the whitespace texts a parser would find on the
+// printed document, where the contents are broken into lines.
+func withLineBreaks(ct HTMLContent) HTMLContent {
+	addBreaks := func(contents []HTMLContent) []HTMLContent {
+		isBlock := func(ct HTMLContent) bool { return isMultiline([]HTMLContent{ct}) }
+		lineBreak := Text("\n\t  ")
+
+		var withBreaks []HTMLContent
+		for i, c := range contents {
+			if i == 0 || isBlock(c) || isBlock(contents[i-1]) {
+				withBreaks = append(withBreaks, lineBreak)
+			}
+			withBreaks = append(withBreaks, withLineBreaks(c))
+		}
+		return append(withBreaks, lineBreak)
+	}
+
+	switch ct.ctType {
+	case Node:
+		ele := ct.child
+		if isInlineElement(ele.tagName) || isVerbatimElement(ele.tagName) || !isMultiline(ele.contents) {
+			return ct
+		}
+		ele.contents = addBreaks(ele.contents)
+		return HTMLContent{child: ele, ctType: Node}
+	case FragmentContent:
+		if !isMultiline(ct.children) {
+			return ct
+		}
+		return Fragment(addBreaks(ct.children)...)
+	}
+	return ct
+}
@@ -2,19 +2,19 @@ package go_ml
 
 /* Htmx custom Attributes */
 func HxPost(url string) HTMLAttribute {
-	return Attr("hx-post", DoubleQuoted, url)
+	return Attr("hx-post", URL, url)
 }
 
 func HxPut(url string) HTMLAttribute {
-	return Attr("hx-put", DoubleQuoted, url)
+	return Attr("hx-put", URL, url)
 }
 
 func HxDelete(url string) HTMLAttribute {
-	return Attr("hx-delete", DoubleQuoted, url)
+	return Attr("hx-delete", URL, url)
 }
 
 func HxGet(url string) HTMLAttribute {
-	return Attr("hx-get", DoubleQuoted, url)
+	return Attr("hx-get", URL, url)
 }
 
 func HxTarget(value string) HTMLAttribute {
@@ -0,0 +1,115 @@
+package go_ml
+
+// Layout is a base tree declaring named slots with Yield, which pages
+// fill or leave with the slot defaults, i.g.:
+//
+//	var Site = NewLayout("Site", Html()(
+//		Head()(Title()(Yield("title", Text("Todo List"))), Yield("head")),
+//		Body()(Yield("main"), Footer()(Yield("footer", Text("bye")))),
+//	))
+//
+//	Site.With(Fill("title", Text("Home")), Fill("main", Div()()))
+//
+// Layouts can extend other layouts, filling some of its slots and
+// declaring new ones. Slots left unfilled by the extending layout can
+// still be filled by the page:
+//
+//	var Section = Site.Extend("Section", Fill("main", Aside()(), Yield("content")))
+//
+//	Section.With(Fill("title", Text("Todos")), Fill("content", Div()()))
+type Layout struct {
+	name string
+	body HTMLContent
+}
+
+// SlotFill holds the contents of a named slot.
+type SlotFill struct {
+	name     string
+	contents []HTMLContent
+}
+
+type layoutNode struct {
+	name  string
+	body  HTMLContent
+	fills map[string][]HTMLContent
+	// slots not filled here are looked up on the extending layout
+	inherit bool
+}
+
+type slotNode struct {
+	name     string
+	defaults []HTMLContent
+}
+
+// fills of the layouts being rendered, the innermost last
+type slotScope struct {
+	fills   map[string][]HTMLContent
+	inherit bool
+}
+
+func NewLayout(name string, body HTMLContent) Layout {
+	return Layout{name: name, body: body}
+}
+
+// Yield declares a named slot, rendering the defaults when it isn't filled.
+func Yield(name string, defaults ...HTMLContent) HTMLContent {
+	return HTMLContent{slot: &slotNode{name: name, defaults: defaults}, ctType: SlotContent}
+}
+
+func Fill(name string, contents ...HTMLContent) SlotFill {
+	return SlotFill{name: name, contents: contents}
+}
+
+// With fills the layout slots. Filling the same slot twice appends the
+// contents.
+func (l Layout) With(fills ...SlotFill) HTMLContent {
+	return l.with(false, fills)
+}
+
+// Extend builds a new layout filling some of the slots of this one.
+func (l Layout) Extend(name string, fills ...SlotFill) Layout {
+	return Layout{name: name, body: l.with(true, fills)}
+}
+
+func (l Layout) with(inherit bool, fills []SlotFill) HTMLContent {
+	node := &layoutNode{
+		name:    l.name,
+		body:    l.body,
+		fills:   make(map[string][]HTMLContent, len(fills)),
+		inherit: inherit,
+	}
+	for _, f := range fills {
+		node.fills[f.name] = append(node.fills[f.name], f.contents...)
+	}
+	return HTMLContent{layout: node, ctType: LayoutContent}
+}
+
+func (node *layoutNode) scope() slotScope {
+	return slotScope{fills: node.fills, inherit: node.inherit}
+}
+
+// resolveSlot looks for the slot contents from the innermost layout to the
+// outermost one it inherits from. The fill contents must be rendered with
+// the scopes of where they were declared, which are returned along with
+// them. The defaults are returned if no layout fills the slot.
+func resolveSlot(scopes []slotScope, slot *slotNode) ([]HTMLContent, []slotScope) {
+	for i := len(scopes) - 1; i >= 0; i-- {
+		if contents, ok := scopes[i].fills[slot.name]; ok {
+			// limit the capacity, so pushing new scopes doesn't
+			// overwrite the current ones
+			return contents, scopes[:i:i]
+		}
+		if !scopes[i].inherit {
+			break
+		}
+	}
+	return slot.defaults, scopes
+}
+
+func layoutPathName(node *layoutNode) string {
+	return "@" + node.name
+}
+
+func slotPathName(slot *slotNode) string {
+	return "#slot(" + slot.name + ")"
+}
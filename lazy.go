@@ -0,0 +1,220 @@
+package go_ml
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+type lazyNode struct {
+	fn func(ctx context.Context) (HTMLContent, error)
+}
+
+const lazyPathName = "#lazy"
+
+// Lazy computes the content while building, i.g.: loading rows from a
+// database. By default each Lazy content is computed when it's reached,
+// but WithConcurrency computes them concurrently, still writing them in
+// the document order.
+//
+// The function gets the build context, with the values of the Provide
+// contents above it.
+func Lazy(fn func(ctx context.Context) (HTMLContent, error)) HTMLContent {
+	return HTMLContent{lazy: &lazyNode{fn: fn}, ctType: LazyContent}
+}
+
+// Compute up to n Lazy contents at the same time. The tree is walked
+// before building, starting every Lazy content which can be reached
+// without rendering components or Consume contents (those are started
+// once they're reached). The first failure cancels the other ones and
+// the build fails with all the errors joined.
+func WithConcurrency(n int) buildOpt {
+	return func(config *buildConfig) {
+		if n < 1 {
+			n = 1
+		}
+		config.lazy = &lazyPool{sem: make(chan struct{}, n), tasks: make(map[lazyKey]*lazyTask)}
+	}
+}
+
+// a Lazy content can be reused on many places of the tree, each one with
+// its own provided values
+type lazyKey struct {
+	node *lazyNode
+	path string
+}
+
+type lazyTask struct {
+	done    chan struct{}
+	content HTMLContent
+	err     error
+}
+
+type lazyPool struct {
+	// canceled on the first failure
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	mu    sync.Mutex
+	tasks map[lazyKey]*lazyTask
+	errs  []error
+}
+
+func (p *lazyPool) start(ctx context.Context) {
+	p.ctx, p.cancel = context.WithCancelCause(ctx)
+}
+
+// stop cancels the tasks still running, without waiting for them.
+func (p *lazyPool) stop() {
+	p.cancel(context.Canceled)
+}
+
+// submit starts computing the content at the path, unless it was already
+// started. The context holds the values provided to the Lazy content.
+func (p *lazyPool) submit(ctx context.Context, node *lazyNode, path elementPath) *lazyTask {
+	key := lazyKey{node: node, path: path.String()}
+	p.mu.Lock()
+	if task, ok := p.tasks[key]; ok {
+		p.mu.Unlock()
+		return task
+	}
+	task := &lazyTask{done: make(chan struct{})}
+	p.tasks[key] = task
+	p.mu.Unlock()
+
+	path = append(elementPath(nil), path...)
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer close(task.done)
+
+		select {
+		case p.sem <- struct{}{}:
+			defer func() { <-p.sem }()
+		case <-p.ctx.Done():
+			task.err = path.wrap(context.Cause(p.ctx))
+			return
+		}
+
+		// values from the given context, canceled along with the pool
+		taskCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		defer context.AfterFunc(p.ctx, cancel)()
+
+		task.content, task.err = node.fn(taskCtx)
+		if task.err != nil {
+			task.err = path.wrap(task.err)
+			p.fail(task.err)
+			return
+		}
+		// the resolved content can hold other Lazy contents
+		p.prefetch(ctx, task.content, append(path, pathEntry{index: -1}), nil)
+	}()
+	return task
+}
+
+func (p *lazyPool) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// errors caused by a previous failure aren't worth to report
+	if p.ctx.Err() == nil || !errors.Is(err, context.Canceled) {
+		p.errs = append(p.errs, err)
+	}
+	p.cancel(err)
+}
+
+// wait cancels every task because of the error and waits them, returning
+// all the failures.
+func (p *lazyPool) wait(err error) error {
+	p.cancel(err)
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.errs) == 0 {
+		return err
+	}
+	return errors.Join(p.errs...)
+}
+
+// prefetch walks the tree starting the Lazy contents, resolving layout
+// slots the same way the build does.
+func (p *lazyPool) prefetch(ctx context.Context, ct HTMLContent, path elementPath, slots []slotScope) {
+	walk := func(contents []HTMLContent, ctx context.Context, slots []slotScope) {
+		for i, c := range contents {
+			path.push("", i)
+			p.prefetch(ctx, c, path, slots)
+			path.pop()
+		}
+	}
+	walkOne := func(ct HTMLContent, ctx context.Context, slots []slotScope) {
+		path.push("", -1)
+		p.prefetch(ctx, ct, path, slots)
+		path.pop()
+	}
+
+	// the index of the content was pushed by the parent, but the root
+	setName := func(name string) {
+		if len(path) > 0 {
+			path[len(path)-1].tagName = name
+		} else {
+			path.push(name, -1)
+		}
+	}
+
+	switch ct.ctType {
+	case Node:
+		setName(ct.child.tagName)
+		walk(ct.child.contents, ctx, slots)
+	case FragmentContent:
+		setName(fragmentPathName)
+		walk(ct.children, ctx, slots)
+	case LayoutContent:
+		setName(layoutPathName(ct.layout))
+		walkOne(ct.layout.body, ctx, append(slots[:len(slots):len(slots)], ct.layout.scope()))
+	case SlotContent:
+		setName(slotPathName(ct.slot))
+		contents, slots := resolveSlot(slots, ct.slot)
+		walk(contents, ctx, slots)
+	case ProviderContent:
+		setName(providerPathName)
+		walk(ct.provider.contents, ct.provider.provide(ctx), slots)
+	case AsyncContent:
+		setName(asyncPathName)
+		walkOne(ct.async.fallback, ctx, slots)
+	case CompiledContent:
+		setName(compiledPathName)
+		walkOne(ct.compiled.source, ctx, slots)
+	case LazyContent:
+		setName(lazyPathName)
+		// nil functions fail when building
+		if ct.lazy.fn != nil {
+			p.submit(ctx, ct.lazy, path)
+		}
+	}
+}
+
+func (cfg *buildConfig) resolveLazy(node *lazyNode) (HTMLContent, error) {
+	if cfg.lazy == nil {
+		content, err := node.fn(cfg.ctx)
+		if err != nil {
+			return content, cfg.buildErr(err)
+		}
+		return content, nil
+	}
+
+	task := cfg.lazy.submit(cfg.ctx, node, cfg.path)
+	select {
+	case <-task.done:
+	case <-cfg.ctx.Done():
+		return HTMLContent{}, cfg.buildErr(cfg.ctx.Err())
+	}
+
+	if task.err != nil {
+		return task.content, cfg.lazy.wait(task.err)
+	}
+	return task.content, nil
+}
@@ -0,0 +1,200 @@
+package go_ml
+
+import (
+	"io"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Minify the output: optional end tags are dropped, the whitespace of
+// the texts is collapsed (and dropped next to block elements), the safe
+// attribute values are unquoted and the class names are sorted, so the
+// pages compress better, i.g.:
+//
+//	<ul class="b a"><li>a</li><li>b  c</li></ul>
+//
+// is written as:
+//
+//	<ul class="a b"><li>a<li>b c</ul>
+//
+// The contents of pre, textarea, script, style and title are written as
+// they are. Inside svg and math the void elements keep their slash and
+// the attribute values their quotes, since the elements there are parsed
+// as XML ones. It replaces the indentation options, the last one wins.
+func WithMinify() buildOpt {
+	return func(config *buildConfig) {
+		config.minify = true
+		config.indentitation.isEnable = false
+	}
+}
+
+// contents of the element being built, to know what follows each child
+type siblingScope struct {
+	parent   string
+	contents []HTMLContent
+}
+
+// next returns the content after the index, skipping the ones rendering
+// nothing, or last if there's no more content in the parent. It returns
+// false when the siblings aren't known, i.g.: inside a component.
+func (s siblingScope) next(index int) (next HTMLContent, last bool, ok bool) {
+	if s.contents == nil || index < 0 || index >= len(s.contents) {
+		return HTMLContent{}, false, false
+	}
+	for _, ct := range s.contents[index+1:] {
+		switch ct.ctType {
+		case Empty, FlushPoint:
+		default:
+			return ct, false, true
+		}
+	}
+	return HTMLContent{}, true, true
+}
+
+// svg elements holding html elements
+// Ref: https://html.spec.whatwg.org/multipage/parsing.html#html-integration-point
+var htmlIntegrationPoints = map[string]struct{}{
+	"foreignObject": {}, "desc": {}, "title": {},
+}
+
+// isForeignElement reports if the element is a svg or math one, which can
+// be self-closed and whose children are svg or math ones too.
+func isForeignElement(tagName string, inForeign bool) bool {
+	return inForeign || tagName == "svg" || tagName == "math"
+}
+
+// hasForeignChildren reports if the children of the foreign element are
+// foreign too.
+func hasForeignChildren(tagName string) bool {
+	_, ok := htmlIntegrationPoints[tagName]
+	return !ok
+}
+
+// elements closing a p element
+var pClosers = map[string]struct{}{
+	"address": {}, "article": {}, "aside": {}, "blockquote": {}, "details": {},
+	"dialog": {}, "div": {}, "dl": {}, "fieldset": {}, "figcaption": {},
+	"figure": {}, "footer": {}, "form": {}, "h1": {}, "h2": {}, "h3": {},
+	"h4": {}, "h5": {}, "h6": {}, "header": {}, "hgroup": {}, "hr": {},
+	"main": {}, "menu": {}, "nav": {}, "ol": {}, "p": {}, "pre": {},
+	"search": {}, "section": {}, "table": {}, "ul": {},
+}
+
+// canOmitEndTag reports if the end tag of the element at index can be
+// dropped, which is only when what follows it is known.
+// Ref: https://html.spec.whatwg.org/multipage/syntax.html#optional-tags
+func (cfg *buildConfig) canOmitEndTag(ele HTMLElement, index int) bool {
+	next, last, ok := cfg.siblings.next(index)
+	followedBy := func(tagNames ...string) bool {
+		return next.ctType == Node && slices.Contains(tagNames, next.child.tagName)
+	}
+
+	switch ele.tagName {
+	case "html", "body":
+		// not followed by a comment, after the root there are only the
+		// async contents
+		return (!ok && index < 0) || last || next.ctType == Node
+	case "head", "colgroup", "caption":
+		// not followed by whitespace or a comment
+		return last || next.ctType == Node
+	case "li":
+		return last || followedBy("li")
+	case "dt":
+		return followedBy("dt", "dd")
+	case "dd":
+		return last || followedBy("dt", "dd")
+	case "p":
+		if last {
+			switch cfg.siblings.parent {
+			case "a", "audio", "del", "ins", "map", "noscript", "video":
+				return false
+			}
+			// custom elements
+			return !strings.Contains(cfg.siblings.parent, "-")
+		}
+		_, closes := pClosers[next.child.tagName]
+		return next.ctType == Node && closes
+	case "rt", "rp":
+		return last || followedBy("rt", "rp")
+	case "optgroup":
+		return last || followedBy("optgroup", "hr")
+	case "option":
+		return last || followedBy("option", "optgroup", "hr")
+	case "thead":
+		return followedBy("tbody", "tfoot")
+	case "tbody":
+		return last || followedBy("tbody", "tfoot")
+	case "tfoot":
+		return last
+	case "tr":
+		return last || followedBy("tr")
+	case "td", "th":
+		return last || followedBy("td", "th")
+	}
+	return false
+}
+
+// attribute values needing quotes
+const unquotedUnsafe = htmlWhitespace + "\"'=<>`"
+
+// writeMinAttr works like writeAttr, unquoting the value when it's safe
+// and sorting the class names.
+func writeMinAttr(w io.StringWriter, attrs []HTMLAttribute, i int, urlPolicy URLPolicy) error {
+	attr := attrs[i]
+	if !isRenderedAttr(attr.attrType) {
+		return nil
+	}
+
+	values := mergedAttrValues(attrs, i)
+	if _, err := w.WriteString(attr.name); err != nil || attr.attrType == Single && len(values) == 0 {
+		return err
+	}
+
+	if attr.attrType == URL {
+		for j, v := range values {
+			values[j] = sanitizeURL(urlPolicy, v)
+		}
+	}
+	value := strings.Join(values, " ")
+	if attr.name == "class" {
+		names := strings.Fields(value)
+		sort.Strings(names)
+		value = strings.Join(slices.Compact(names), " ")
+	}
+
+	if value != "" && !strings.ContainsAny(value, unquotedUnsafe) {
+		if _, err := w.WriteString("="); err != nil {
+			return err
+		}
+		return writeEscaped(w, value, &attrEscapes)
+	}
+
+	if _, err := w.WriteString(`="`); err != nil {
+		return err
+	}
+	if err := writeEscaped(w, value, &attrEscapes); err != nil {
+		return err
+	}
+	_, err := w.WriteString(`"`)
+	return err
+}
+
+// writeCollapsed works like writeEscaped, replacing each whitespace run by
+// a single space.
+func writeCollapsed(w io.StringWriter, s string, escapes *[256]string) error {
+	for s != "" {
+		i := strings.IndexAny(s, htmlWhitespace)
+		if i < 0 {
+			return writeEscaped(w, s, escapes)
+		}
+		if err := writeEscaped(w, s[:i], escapes); err != nil {
+			return err
+		}
+		if _, err := w.WriteString(" "); err != nil {
+			return err
+		}
+		s = strings.TrimLeft(s[i:], htmlWhitespace)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package go_ml
+
+import "strings"
+
+// state of the pretty-printer, also used by the minifier
+type prettyState struct {
+	// the contents are written one per line, otherwise they're written as
+	// they are
+	block bool
+	// the last content is inline, so the next inline one goes on the same
+	// line
+	inRun bool
+	// trailing whitespace of the last text, only written if the line goes on
+	pending string
+	// inside an element holding preformatted text
+	verbatim bool
+	// inside svg or math, where the elements are parsed as XML ones
+	foreign bool
+}
+
+// elements rendered as blocks by default, or never rendered, so the
+// whitespace around them isn't meaningful. The other ones, including the
+// custom elements, are taken as inline ones.
+var blockElements = map[string]struct{}{
+	"address": {}, "article": {}, "aside": {}, "base": {}, "blockquote": {},
+	"body": {}, "caption": {}, "col": {}, "colgroup": {}, "dd": {},
+	"details": {}, "dialog": {}, "div": {}, "dl": {}, "dt": {}, "fieldset": {},
+	"figcaption": {}, "figure": {}, "footer": {}, "form": {}, "h1": {},
+	"h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {}, "head": {},
+	"header": {}, "hgroup": {}, "hr": {}, "html": {}, "legend": {}, "li": {},
+	"link": {}, "main": {}, "menu": {}, "meta": {}, "nav": {}, "ol": {},
+	"optgroup": {}, "option": {}, "p": {}, "pre": {}, "search": {},
+	"section": {}, "summary": {}, "table": {}, "tbody": {}, "td": {},
+	"tfoot": {}, "th": {}, "thead": {}, "title": {}, "tr": {}, "ul": {},
+}
+
+// elements holding preformatted or raw text, their contents are always
+// written as they are
+var verbatimElements = map[string]struct{}{
+	"pre": {}, "textarea": {}, "script": {}, "style": {}, "title": {},
+}
+
+// ASCII whitespace, ref: https://infra.spec.whatwg.org/#ascii-whitespace
+const htmlWhitespace = " \t\n\f\r"
+
+func isInlineElement(tagName string) bool {
+	_, ok := blockElements[tagName]
+	return !ok
+}
+
+func isVerbatimElement(tagName string) bool {
+	_, ok := verbatimElements[tagName]
+	return ok
+}
+
+// isMultiline reports if the contents must be written one per line, which
+// is when any of them is a block element. The contents only known while
+// building (components, consumers, slots...) are taken as block ones.
+func isMultiline(contents []HTMLContent) bool {
+	for _, ct := range contents {
+		switch ct.ctType {
+		case Node:
+			if !isInlineElement(ct.child.tagName) {
+				return true
+			}
+		case Raw, Escaped, Empty, FlushPoint:
+		case FragmentContent:
+			if isMultiline(ct.children) {
+				return true
+			}
+		case ProviderContent:
+			if isMultiline(ct.provider.contents) {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// breakLine starts a new line indented for the contents at tagDepth,
+// unless nothing was written yet.
+func (cfg *buildConfig) breakLine(tagDepth int) error {
+	if cfg.out.written == 0 || cfg.minify {
+		return nil
+	}
+	cfg.out.markBreak()
+	return writeIndent(cfg.out, (tagDepth-1)*int(cfg.indentitation.indentationLevel))
+}
+
+// reformats reports if the whitespace between the contents is rewritten.
+func (cfg *buildConfig) reformats() bool {
+	return cfg.indentitation.isEnable || cfg.minify
+}
+
+// startContent places the next content: block contents and the inline
+// ones after a block content go on a new line.
+func (cfg *buildConfig) startContent(inline bool, tagDepth int) error {
+	p := &cfg.pretty
+	if !cfg.reformats() || !p.block {
+		return nil
+	}
+
+	pending := p.pending
+	p.pending = ""
+	if inline && p.inRun {
+		if cfg.minify && pending != "" {
+			pending = " "
+		}
+		_, err := cfg.out.WriteString(pending)
+		return err
+	}
+	p.inRun = inline
+	return cfg.breakLine(tagDepth)
+}
+
+// writeText writes a text content, escaping it if asked.
+func (cfg *buildConfig) writeText(text string, escape bool, tagDepth int) (int, error) {
+	out := cfg.out
+	start := out.written
+
+	if cfg.reformats() && cfg.pretty.block {
+		// the minifier keeps a single space between the texts
+		if !cfg.pretty.inRun || cfg.minify && cfg.pretty.pending != "" {
+			text = strings.TrimLeft(text, htmlWhitespace)
+		}
+		trimmed := strings.TrimRight(text, htmlWhitespace)
+		if trimmed == "" {
+			// only separates two inline contents on the same line
+			if cfg.pretty.inRun && cfg.pretty.pending == "" {
+				cfg.pretty.pending = text
+			}
+			return 0, nil
+		}
+
+		if err := cfg.startContent(true, tagDepth); err != nil {
+			return out.since(start), err
+		}
+		cfg.pretty.pending = text[len(trimmed):]
+		text = trimmed
+	}
+
+	if !escape {
+		_, err := out.WriteString(text)
+		return out.since(start), err
+	}
+	if cfg.minify && !cfg.pretty.verbatim {
+		err := writeCollapsed(out, text, &textEscapes)
+		return out.since(start), err
+	}
+	err := writeEscaped(out, text, &textEscapes)
+	return out.since(start), err
+}
+
+// startOwnLine writes the next contents on their own line, as they are,
+// returning the state to restore after them. The minifier doesn't know if
+// they're block ones, so it keeps the whitespace around them.
+func (cfg *buildConfig) startOwnLine(tagDepth int) (prettyState, error) {
+	if err := cfg.startContent(cfg.minify, tagDepth); err != nil {
+		return cfg.pretty, err
+	}
+	outer := cfg.pretty
+	cfg.pretty = prettyState{verbatim: outer.verbatim, foreign: outer.foreign}
+	return outer, nil
+}
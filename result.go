@@ -0,0 +1,32 @@
+package go_ml
+
+import "time"
+
+// RenderResult holds the metrics of a build.
+type RenderResult struct {
+	BytesWritten int
+	// elements written, including the async and lazy ones
+	Elements int
+	// depth of the deepest element, the root element has depth 1
+	MaxDepth int
+	Duration time.Duration
+	// timings by component (and layout) name
+	Components map[string]ComponentTiming
+}
+
+// ComponentTiming sums every build of a component, including the
+// components built inside it.
+type ComponentTiming struct {
+	Count    int
+	Duration time.Duration
+}
+
+func (r *RenderResult) trackComponent(name string, d time.Duration) {
+	if r.Components == nil {
+		r.Components = make(map[string]ComponentTiming)
+	}
+	timing := r.Components[name]
+	timing.Count++
+	timing.Duration += d
+	r.Components[name] = timing
+}
@@ -0,0 +1,75 @@
+package go_ml
+
+import "strings"
+
+// Same placeholder used by html/template, it makes easy to grep for
+// rejected URLs in the rendered pages.
+const UnsafeURLPlaceholder = "#ZgotmplZ"
+
+// URLPolicy reports if a URL attribute value is safe to be rendered.
+type URLPolicy func(url string) bool
+
+// Allows http, https and mailto schemes and any relative URL.
+var DefaultURLPolicy = AllowURLSchemes("http", "https", "mailto")
+
+// AllowURLSchemes builds a policy that accepts relative URLs and absolute
+// URLs with one of the given schemes (case insensitive).
+func AllowURLSchemes(schemes ...string) URLPolicy {
+	allowed := make(map[string]struct{}, len(schemes))
+	for _, s := range schemes {
+		allowed[strings.ToLower(s)] = struct{}{}
+	}
+
+	return func(url string) bool {
+		scheme, ok := urlScheme(url)
+		if !ok {
+			return false
+		}
+		if scheme == "" {
+			return true
+		}
+		_, ok = allowed[scheme]
+		return ok
+	}
+}
+
+// urlScheme returns the lower-cased scheme of the URL or an empty string
+// for relative URLs. It returns false if the scheme is malformed.
+func urlScheme(url string) (string, bool) {
+	// browsers ignore surrounding spaces and tabs/new lines in the middle
+	// of the URL, so "java\tscript:" is still a javascript URL.
+	url = strings.TrimFunc(url, func(r rune) bool { return r <= ' ' })
+	if strings.ContainsAny(url, "\t\n\r") {
+		url = strings.NewReplacer("\t", "", "\n", "", "\r", "").Replace(url)
+	}
+
+	for i, r := range url {
+		switch {
+		case r == ':':
+			if i == 0 {
+				return "", false
+			}
+			return strings.ToLower(url[:i]), true
+		case r == '/', r == '?', r == '#':
+			// relative URL
+			return "", true
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case i > 0 && ('0' <= r && r <= '9' || r == '+' || r == '-' || r == '.'):
+		default:
+			// not a scheme, so it can only be a relative path if it
+			// doesn't contain ':' before the first path segment
+			if j := strings.IndexAny(url, ":/?#"); j >= 0 && url[j] == ':' {
+				return "", false
+			}
+			return "", true
+		}
+	}
+	return "", true
+}
+
+func sanitizeURL(policy URLPolicy, url string) string {
+	if policy == nil || policy(url) {
+		return url
+	}
+	return UnsafeURLPlaceholder
+}
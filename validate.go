@@ -0,0 +1,374 @@
+package go_ml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrContentModel = errors.New("content model violation")
+
+// Ref: https://html.spec.whatwg.org/multipage/dom.html#kinds-of-content
+type contentCategory uint16
+
+const (
+	metadataContent contentCategory = 1 << iota
+	flowContent
+	sectioningContent
+	headingContent
+	phrasingContent
+	embeddedContent
+	interactiveContent
+	scriptSupportingContent
+)
+
+// What an element accepts as children.
+// Ref: https://html.spec.whatwg.org/multipage/dom.html#content-models
+type contentModel struct {
+	// categories accepted as children
+	categories contentCategory
+	// tags accepted as children besides the categories
+	tags []string
+	// non whitespace text accepted as children
+	text bool
+	// transparent elements take the content model of their parent
+	transparent bool
+}
+
+func (m contentModel) accepts(ele HTMLElement) bool {
+	categories, ok := elementCategories[ele.tagName]
+	if !ok || categories&m.categories != 0 {
+		// custom elements are accepted anywhere
+		return true
+	}
+	for _, t := range m.tags {
+		if t == ele.tagName {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	flowModel     = contentModel{categories: flowContent, text: true}
+	phrasingModel = contentModel{categories: phrasingContent, text: true}
+	textModel     = contentModel{text: true}
+	tableRowModel = contentModel{tags: []string{"tr", "script", "template"}}
+	listModel     = contentModel{tags: []string{"li", "script", "template"}}
+)
+
+// Elements missing here (template, svg, custom elements...) aren't checked.
+var contentModels = map[string]contentModel{
+	"html":     {tags: []string{"head", "body"}},
+	"head":     {categories: metadataContent},
+	"title":    textModel,
+	"textarea": textModel,
+	"option":   textModel,
+	"script":   textModel,
+	"style":    textModel,
+
+	"body":       flowModel,
+	"main":       flowModel,
+	"article":    flowModel,
+	"aside":      flowModel,
+	"nav":        flowModel,
+	"section":    flowModel,
+	"header":     flowModel,
+	"footer":     flowModel,
+	"address":    flowModel,
+	"blockquote": flowModel,
+	"div":        flowModel,
+	"figure":     flowModel,
+	"figcaption": flowModel,
+	"li":         flowModel,
+	"dt":         flowModel,
+	"dd":         flowModel,
+	"td":         flowModel,
+	"th":         flowModel,
+	"caption":    flowModel,
+	"details":    flowModel,
+	"dialog":     flowModel,
+	"fieldset":   flowModel,
+	"form":       flowModel,
+	"search":     flowModel,
+
+	"p":        phrasingModel,
+	"pre":      phrasingModel,
+	"h1":       phrasingModel,
+	"h2":       phrasingModel,
+	"h3":       phrasingModel,
+	"h4":       phrasingModel,
+	"h5":       phrasingModel,
+	"h6":       phrasingModel,
+	"span":     phrasingModel,
+	"b":        phrasingModel,
+	"i":        phrasingModel,
+	"u":        phrasingModel,
+	"s":        phrasingModel,
+	"em":       phrasingModel,
+	"strong":   phrasingModel,
+	"small":    phrasingModel,
+	"sub":      phrasingModel,
+	"sup":      phrasingModel,
+	"abbr":     phrasingModel,
+	"cite":     phrasingModel,
+	"code":     phrasingModel,
+	"data":     phrasingModel,
+	"dfn":      phrasingModel,
+	"kbd":      phrasingModel,
+	"mark":     phrasingModel,
+	"q":        phrasingModel,
+	"samp":     phrasingModel,
+	"time":     phrasingModel,
+	"var":      phrasingModel,
+	"bdi":      phrasingModel,
+	"bdo":      phrasingModel,
+	"label":    phrasingModel,
+	"button":   phrasingModel,
+	"legend":   phrasingModel,
+	"output":   phrasingModel,
+	"meter":    phrasingModel,
+	"progress": phrasingModel,
+	"summary":  phrasingModel,
+	"rt":       phrasingModel,
+	"rp":       textModel,
+	"ruby":     {categories: phrasingContent, tags: []string{"rt", "rp"}, text: true},
+
+	"table":    {tags: []string{"caption", "colgroup", "thead", "tbody", "tfoot", "tr", "script", "template"}},
+	"thead":    tableRowModel,
+	"tbody":    tableRowModel,
+	"tfoot":    tableRowModel,
+	"tr":       {tags: []string{"td", "th", "script", "template"}},
+	"colgroup": {tags: []string{"col", "template"}},
+	"ul":       listModel,
+	"ol":       listModel,
+	"menu":     listModel,
+	"dl":       {tags: []string{"dt", "dd", "div", "script", "template"}},
+	"select":   {tags: []string{"option", "optgroup", "hr", "script", "template"}},
+	"optgroup": {tags: []string{"option", "script", "template"}},
+	"datalist": {categories: phrasingContent, tags: []string{"option"}, text: true},
+	"picture":  {tags: []string{"source", "img", "script", "template"}},
+	"hgroup":   {tags: []string{"p", "h1", "h2", "h3", "h4", "h5", "h6", "script", "template"}},
+
+	"a":        {transparent: true},
+	"ins":      {transparent: true},
+	"del":      {transparent: true},
+	"map":      {transparent: true},
+	"canvas":   {transparent: true},
+	"slot":     {transparent: true},
+	"noscript": {transparent: true},
+	"object":   {transparent: true},
+	"audio":    {transparent: true, tags: []string{"source", "track"}},
+	"video":    {transparent: true, tags: []string{"source", "track"}},
+}
+
+// Some elements are only interactive depending on their attributes.
+func isInteractive(ele HTMLElement) bool {
+	if elementCategories[ele.tagName]&interactiveContent == 0 {
+		return false
+	}
+
+	hasAttr := func(name string) (values []string, ok bool) {
+		for _, attr := range ele.attrs {
+			if attr.name == name && attr.attrType != None {
+				values, ok = append(values, attr.values...), true
+			}
+		}
+		return
+	}
+
+	switch ele.tagName {
+	case "a":
+		_, ok := hasAttr("href")
+		return ok
+	case "img":
+		_, ok := hasAttr("usemap")
+		return ok
+	case "audio", "video":
+		_, ok := hasAttr("controls")
+		return ok
+	case "input":
+		values, _ := hasAttr("type")
+		return len(values) == 0 || !strings.EqualFold(values[len(values)-1], "hidden")
+	}
+	return true
+}
+
+type validator struct {
+	ctx   context.Context
+	path  elementPath
+	slots []slotScope
+	errs  []error
+}
+
+// the content model which the children of an element are checked against
+// and the elements forbidden as descendants
+type validationScope struct {
+	model   contentModel
+	checked bool
+	// i.g.: a and button can't have interactive descendants
+	noInteractive bool
+	noForm        bool
+	noLabel       bool
+}
+
+// Validate walks the tree reporting the content model rules it violates,
+// such as flow content in phrasing contexts or illegal table and list
+// children. Each violation is a *BuildError wrapping ErrContentModel and
+// all of them are joined in the returned error.
+//
+// The Async and Lazy contents are only known when building, so only the
+// fallbacks of the Async contents are validated.
+//
+// Ref: https://html.spec.whatwg.org/multipage/dom.html#content-models
+func Validate(root HTMLContent) error {
+	return ValidateContext(context.Background(), root)
+}
+
+// ValidateContext validates the tree giving the context to its Consume
+// contents, the same way BuildDOMContext does.
+func ValidateContext(ctx context.Context, root HTMLContent) error {
+	v := &validator{ctx: ctx}
+	v.content(root, -1, validationScope{})
+	return errors.Join(v.errs...)
+}
+
+// Validate the tree before writing anything, failing the build with the
+// violations reported by Validate.
+func WithValidation() buildOpt {
+	return func(config *buildConfig) {
+		config.validate = true
+	}
+}
+
+func (v *validator) report(format string, args ...any) {
+	v.errs = append(v.errs, v.path.wrap(fmt.Errorf("%w: "+format, append([]any{ErrContentModel}, args...)...)))
+}
+
+func (v *validator) content(ct HTMLContent, index int, scope validationScope) {
+	switch ct.ctType {
+	case Node:
+		v.element(ct.child, index, scope)
+	case Raw, Escaped:
+		if scope.checked && !scope.model.text && strings.TrimSpace(ct.raw.text) != "" {
+			v.report("text isn't allowed")
+		}
+	case ComponentContent:
+		if ct.component == nil || !canRender(ct.component) {
+			return
+		}
+		v.path.push(componentPathName(ct.component), index)
+		defer v.path.pop()
+		v.content(ct.component.Render(), -1, scope)
+	case LayoutContent:
+		v.path.push(layoutPathName(ct.layout), index)
+		defer v.path.pop()
+
+		outerSlots := v.slots
+		v.slots = append(v.slots, ct.layout.scope())
+		defer func() { v.slots = outerSlots }()
+		v.content(ct.layout.body, -1, scope)
+	case SlotContent:
+		v.path.push(slotPathName(ct.slot), index)
+		defer v.path.pop()
+
+		outerSlots := v.slots
+		var contents []HTMLContent
+		contents, v.slots = resolveSlot(v.slots, ct.slot)
+		defer func() { v.slots = outerSlots }()
+		for i, c := range contents {
+			v.content(c, i, scope)
+		}
+	case ProviderContent:
+		v.path.push(providerPathName, index)
+		defer v.path.pop()
+
+		outerCtx := v.ctx
+		v.ctx = ct.provider.provide(v.ctx)
+		defer func() { v.ctx = outerCtx }()
+		for i, c := range ct.provider.contents {
+			v.content(c, i, scope)
+		}
+	case ConsumerContent:
+		if ct.consumer == nil {
+			return
+		}
+		v.path.push(consumerPathName, index)
+		defer v.path.pop()
+		v.content(ct.consumer(v.ctx), -1, scope)
+	case AsyncContent:
+		// only the fallback is known before building
+		v.path.push(asyncPathName, index)
+		defer v.path.pop()
+		v.content(ct.async.fallback, -1, scope)
+	case LazyContent:
+		// the content is only known when building, computing it here would
+		// load it twice
+		return
+	case CompiledContent:
+		v.path.push(compiledPathName, index)
+		defer v.path.pop()
+		v.content(ct.compiled.source, -1, scope)
+	case CachedContent:
+		if ct.cached.fn == nil {
+			return
+		}
+		v.path.push(cachedPathName(ct.cached), index)
+		defer v.path.pop()
+		v.content(ct.cached.fn(), -1, scope)
+	case FragmentContent:
+		v.path.push(fragmentPathName, index)
+		defer v.path.pop()
+		for i, c := range ct.children {
+			v.content(c, i, scope)
+		}
+	}
+}
+
+func (v *validator) element(ele HTMLElement, index int, scope validationScope) {
+	v.path.push(ele.tagName, index)
+	defer v.path.pop()
+
+	if scope.checked && !scope.model.accepts(ele) {
+		parent := "the parent"
+		for i := len(v.path) - 2; i >= 0; i-- {
+			if v.path[i].isElement() {
+				parent = "<" + v.path[i].tagName + ">"
+				break
+			}
+		}
+		v.report("<%s> isn't allowed in %s", ele.tagName, parent)
+	}
+	if scope.noInteractive && isInteractive(ele) {
+		v.report("interactive <%s> can't be nested in <a> or <button>", ele.tagName)
+	}
+	if scope.noForm && ele.tagName == "form" {
+		v.report("<form> can't be nested in another <form>")
+	}
+	if scope.noLabel && ele.tagName == "label" {
+		v.report("<label> can't be nested in another <label>")
+	}
+
+	switch ele.tagName {
+	case "a", "button":
+		scope.noInteractive = true
+	case "form":
+		scope.noForm = true
+	case "label":
+		scope.noLabel = true
+	}
+
+	if model, ok := contentModels[ele.tagName]; !ok {
+		scope.checked = false
+	} else if model.transparent {
+		// keeps the parent model, adding what the element accepts itself
+		scope.model.tags = append(model.tags[:len(model.tags):len(model.tags)], scope.model.tags...)
+	} else {
+		scope.model, scope.checked = model, true
+	}
+
+	for i, ct := range ele.contents {
+		v.content(ct, i, scope)
+	}
+}
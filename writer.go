@@ -0,0 +1,137 @@
+package go_ml
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync"
+)
+
+// big enough to hold most of the pages between flushes
+const writerBufferSize = 4 << 10
+
+var bufferPool = sync.Pool{
+	New: func() any { return bufio.NewWriterSize(nil, writerBufferSize) },
+}
+
+// renderWriter buffers everything written by a build, counting the
+// written bytes. Write errors are sticky, so a sequence of writes only
+// needs the last one checked.
+type renderWriter struct {
+	buf     *bufio.Writer
+	written int
+	// copies of what's written, i.g.: by the cached contents being built
+	recorders []*recorder
+}
+
+// recorder copies what's written, knowing where the line breaks of the
+// pretty-printer are, so the copy can be indented for another depth.
+type recorder struct {
+	buf bytes.Buffer
+	// offsets of the new lines starting the breaks
+	breaks []int
+}
+
+func newRenderWriter(w io.Writer) *renderWriter {
+	buf := bufferPool.Get().(*bufio.Writer)
+	buf.Reset(w)
+	return &renderWriter{buf: buf}
+}
+
+func (w *renderWriter) WriteString(s string) (int, error) {
+	n, err := w.buf.WriteString(s)
+	w.written += n
+	for _, rec := range w.recorders {
+		rec.buf.WriteString(s[:n])
+	}
+	return n, err
+}
+
+func (w *renderWriter) Write(p []byte) (int, error) {
+	n, err := w.buf.Write(p)
+	w.written += n
+	for _, rec := range w.recorders {
+		rec.buf.Write(p[:n])
+	}
+	return n, err
+}
+
+// markBreak tells the recorders the next byte starts a line break.
+func (w *renderWriter) markBreak() {
+	for _, rec := range w.recorders {
+		rec.breaks = append(rec.breaks, rec.buf.Len())
+	}
+}
+
+// since returns how many bytes were written after the start mark.
+func (w *renderWriter) since(start int) int {
+	return w.written - start
+}
+
+// flush sends the buffered bytes to the underlying writer.
+func (w *renderWriter) flush() error {
+	return w.buf.Flush()
+}
+
+// release flushes the buffer and puts it back to the pool, the writer
+// can't be used anymore.
+func (w *renderWriter) release() error {
+	err := w.buf.Flush()
+	w.buf.Reset(nil)
+	bufferPool.Put(w.buf)
+	w.buf = nil
+	return err
+}
+
+const spaces = "                                                                "
+
+// writeIndent writes a new line followed by n spaces.
+func writeIndent(w io.StringWriter, n int) error {
+	if _, err := w.WriteString("\n"); err != nil {
+		return err
+	}
+	for n > 0 {
+		chunk := min(n, len(spaces))
+		if _, err := w.WriteString(spaces[:chunk]); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
+
+// same replacements of html.EscapeString
+var textEscapes = [256]string{
+	'&':  "&amp;",
+	'\'': "&#39;",
+	'<':  "&lt;",
+	'>':  "&gt;",
+	'"':  "&#34;",
+}
+
+// double-quoted values only need to escape the ampersand and the quote itself
+var attrEscapes = [256]string{
+	'&': "&amp;",
+	'"': "&#34;",
+}
+
+// writeEscaped writes s replacing the bytes found on escapes, without
+// building an escaped copy of it.
+func writeEscaped(w io.StringWriter, s string, escapes *[256]string) error {
+	last := 0
+	for i := 0; i < len(s); i++ {
+		esc := escapes[s[i]]
+		if esc == "" {
+			continue
+		}
+		if _, err := w.WriteString(s[last:i]); err != nil {
+			return err
+		}
+		if _, err := w.WriteString(esc); err != nil {
+			return err
+		}
+		last = i + 1
+	}
+	_, err := w.WriteString(s[last:])
+	return err
+}